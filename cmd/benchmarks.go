@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"masbench/internals/config"
+	"masbench/internals/selector"
+)
+
+// selectorHelp describes the benchmark selectors in the help of the
+// commands taking benchmark names
+const selectorHelp = `Benchmarks can be selected with:
+  astar-v1               the benchmark called astar-v1
+  'astar-*'              a glob on the names, quoted so the shell does not expand it
+  tag:nightly            the benchmarks tagged nightly, tags need the tag: prefix
+  latest, latest~2       the last benchmark run, or the one run 2 before it
+  since:2026-09-01       the benchmarks run on or after a date
+  until:2026-09-30       the benchmarks run on or before a date
+  since:2026-09-01..2026-09-30
+                         the benchmarks run within a range of dates`
+
+// newSelector lists the benchmarks of the benchmark folder, exiting if the
+// folder cannot be read
+func newSelector() *selector.Selector {
+	s, err := selector.New(config.GetConfig().BenchmarkFolder)
+	if err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+	return s
+}
+
+// selectBenchmarks resolves the selectors to benchmark names, exiting if
+// one of them selects nothing
+func selectBenchmarks(selectors []string) []string {
+	names, err := newSelector().Resolve(selectors)
+	if err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+	return names
+}
+
+// selectBenchmark resolves a selector to a single benchmark name, exiting if
+// it selects none or several
+func selectBenchmark(sel string) string {
+	name, err := newSelector().ResolveOne(sel)
+	if err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+	return name
+}
+
+// selectBenchmarkFolders resolves the selectors to benchmark names like
+// selectBenchmarks, also selecting benchmarks without results by name or glob
+func selectBenchmarkFolders(selectors []string) []string {
+	s, err := selector.NewAll(config.GetConfig().BenchmarkFolder)
+	if err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+	names, err := s.Resolve(selectors)
+	if err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+	return names
+}
+
+// selectBenchmarkFolder resolves a selector to a single benchmark name like
+// selectBenchmark, also selecting benchmarks without results by name or glob
+func selectBenchmarkFolder(sel string) string {
+	s, err := selector.NewAll(config.GetConfig().BenchmarkFolder)
+	if err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+	name, err := s.ResolveOne(sel)
+	if err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+	return name
+}
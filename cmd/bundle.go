@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"masbench/internals/bundle"
+	"masbench/internals/config"
+)
+
+var exportOutput string
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importBundleCmd)
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Write the bundle to this file, e.g. bundle.tar.gz")
+	exportCmd.MarkFlagRequired("output")
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export <benchmarks...> -o <bundle.tar.gz>",
+	Short: "Package benchmarks into a bundle to share them",
+	Long: `Package benchmarks into a .tar.gz bundle to share them with teammates or
+move them to another machine. The bundle holds the whole folder of every
+benchmark, with its results, logs, notes and metadata, and a manifest with
+the checksum of every file. Reports are not exported.
+
+` + selectorHelp + `
+
+Examples:
+  masbench export astar-v3 -o astar.tar.gz
+  masbench export tag:nightly 'greedy-*' -o nightly.tar.gz`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.GetConfig()
+		names := selectBenchmarkFolders(args)
+
+		file, err := os.Create(exportOutput)
+		if err != nil {
+			fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+			os.Exit(1)
+		}
+		manifest, err := bundle.Write(file, cfg.BenchmarkFolder, names)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(exportOutput)
+			fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+			os.Exit(1)
+		}
+
+		for _, name := range names {
+			fmt.Printf("Exported %s\n", name)
+		}
+		fmt.Printf(colorGreen+"Wrote %d benchmarks, %d files, to %s%s\n", len(names), len(manifest.Files), exportOutput, colorReset)
+	},
+}
+
+var importBundleCmd = &cobra.Command{
+	Use:   "import-bundle <bundle.tar.gz>",
+	Short: "Import the benchmarks of a bundle made with masbench export",
+	Long: `Import the benchmarks of a bundle made with masbench export into the
+benchmark folder. The checksums of the bundle are verified first, nothing
+is imported from a corrupted bundle.
+
+A benchmark whose name is taken is imported under a new name, <name>-2,
+<name>-3 and so on, with the files named after it renamed.
+
+Examples:
+  masbench import-bundle nightly.tar.gz`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := importBundle(args[0]); err != nil {
+			fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+			os.Exit(1)
+		}
+	},
+}
+
+// importBundle verifies and unpacks a bundle into a hidden folder of the
+// benchmark folder, then moves its benchmarks into place
+func importBundle(bundlePath string) error {
+	cfg := config.GetConfig()
+	file, err := os.Open(bundlePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := os.MkdirAll(cfg.BenchmarkFolder, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", cfg.BenchmarkFolder, err)
+	}
+	staging, err := os.MkdirTemp(cfg.BenchmarkFolder, ".import-")
+	if err != nil {
+		return fmt.Errorf("failed to create a staging folder: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	manifest, err := bundle.Extract(file, staging)
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", bundlePath, err)
+	}
+	for _, name := range manifest.Benchmarks {
+		if !validBenchmarkName(name) {
+			return fmt.Errorf("invalid benchmark name %q in %s", name, bundlePath)
+		}
+	}
+	defer refreshIndex()
+
+	for _, name := range manifest.Benchmarks {
+		target := availableBenchmarkName(name)
+		if target != name {
+			if err := renameBenchmarkFiles(filepath.Join(staging, name), name, target); err != nil {
+				return err
+			}
+		}
+		if err := os.Rename(filepath.Join(staging, name), filepath.Join(cfg.BenchmarkFolder, target)); err != nil {
+			return fmt.Errorf("failed to import %s: %w", name, err)
+		}
+
+		if target == name {
+			fmt.Printf("Imported %s\n", name)
+			continue
+		}
+		if err := renameInMetadata(target); err != nil {
+			return err
+		}
+		fmt.Printf(colorYellow+"Imported %s as %s, %s already exists%s\n", name, target, name, colorReset)
+	}
+	fmt.Printf(colorGreen+"Imported %d benchmarks from %s%s\n", len(manifest.Benchmarks), bundlePath, colorReset)
+	return nil
+}
+
+// availableBenchmarkName returns name, or name-2, name-3 and so on if it is
+// taken
+func availableBenchmarkName(name string) string {
+	candidate := name
+	for i := 2; checkNewBenchmarkName(candidate) != nil; i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	return candidate
+}
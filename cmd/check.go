@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	"masbench/internals/checker"
+	"masbench/internals/comparator"
+	"masbench/internals/config"
+	"masbench/internals/junit"
+	"masbench/internals/models"
+	"masbench/internals/utils"
+)
+
+// exitRulesViolated is the exit status of check when rules are violated, so
+// that CI can tell a regression from a check that could not run, which exits
+// with status 1 like every command
+const exitRulesViolated = 2
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+	addToleranceFlags(checkCmd)
+	addJUnitFlag(checkCmd)
+	checkCmd.Flags().String("against", "", "Baseline benchmark the candidate is checked against")
+	checkCmd.Flags().String("rules", "", "YAML file with the rules to check, instead of the Check section of masbench_config.yml")
+	checkCmd.MarkFlagRequired("against")
+}
+
+var checkCmd = &cobra.Command{
+	Use:   "check <candidate> --against <baseline>",
+	Short: "Check a benchmark for regressions against a baseline, for CI",
+	Long: `Check a benchmark for regressions against a baseline and exit with a
+non-zero status if any rule is violated, to fail a CI build.
+
+The candidate is compared against the baseline exactly like compare does,
+then every rule of the Check section of masbench_config.yml is evaluated:
+
+  Check:
+    Rules:
+      - solved >= baseline
+      - no newly-unsolved levels
+      - time.geomean <= 1.05
+      - actions.regressions <= 2
+    Allow:
+      SAsoko3_48: [time]
+      MAflaky: [all]
+
+Rules have the form "<quantity> <op> <value>", where value is a number or
+"baseline". "no <quantity>" is a shorthand for "<quantity> == 0".
+
+Quantities:
+  solved                 levels solved by the candidate
+  newly-unsolved         levels solved by the baseline only
+  newly-solved           levels solved by the candidate only
+  missing                levels of the baseline the candidate did not run
+  par2                   PAR-2 ratio, candidate / baseline
+  <metric>.geomean       geometric mean ratio, candidate / baseline
+  <metric>.median        median ratio
+  <metric>.total         total ratio
+  <metric>.improved      fraction of levels improved
+  <metric>.regressed     fraction of levels regressed
+  <metric>.regressions   number of levels regressed
+
+Metrics are time, actions, generated, explored and memory. Ratios are
+computed over the levels solved by both benchmarks. A rule whose quantity
+cannot be computed, such as a ratio when no level is solved by both
+benchmarks, fails as not evaluable.
+
+check exits with status 2 when a rule fails, and with status 1 when the
+check cannot run, e.g. because of a missing benchmark or invalid rules.
+
+Allow lists, per level, the metrics whose regressions are ignored, or all
+to ignore the level entirely. Allowed metrics count as matching the baseline.
+
+Use --junit to also write one JUnit testcase per level, failing the levels
+the candidate did not solve and the levels where a metric regressed beyond
+the tolerances.
+
+Examples:
+  masbench check feature-x --against main
+  masbench check feature-x --against main --rules ci_rules.yml
+  masbench check feature-x --against main --junit check.xml
+  masbench check latest --against tag:release
+
+The candidate and the baseline can be any selector matching a single
+benchmark, e.g. latest or latest~1.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			fmt.Println(colorRed + "Error: You must provide exactly one benchmark to check." + colorReset)
+			os.Exit(1)
+		}
+
+		baselineName, err := cmd.Flags().GetString("against")
+		if err != nil {
+			fmt.Println("failed to read flag:", err)
+			os.Exit(1)
+		}
+		rulesPath, err := cmd.Flags().GetString("rules")
+		if err != nil {
+			fmt.Println("failed to read flag:", err)
+			os.Exit(1)
+		}
+
+		if !checkBenchmark(selectBenchmark(args[0]), selectBenchmark(baselineName), rulesPath) {
+			os.Exit(exitRulesViolated)
+		}
+	},
+}
+
+// checkBenchmark checks the candidate against the baseline and prints the
+// outcome of every rule, it reports whether all of them passed
+func checkBenchmark(candidateName, baselineName, rulesPath string) bool {
+	cfg := config.GetConfig()
+
+	checkConfig := cfg.Check
+	if rulesPath != "" {
+		var err error
+		checkConfig, err = loadCheckRules(rulesPath)
+		if err != nil {
+			fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+			os.Exit(1)
+		}
+	}
+
+	candidate, err := utils.LoadCSV(resultsPathOrExit(candidateName))
+	if err != nil {
+		fmt.Printf(colorRed+"Error reading candidate CSV: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+	baseline, err := utils.LoadCSV(resultsPathOrExit(baselineName))
+	if err != nil {
+		fmt.Printf(colorRed+"Error reading baseline CSV: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+
+	opts := comparisonOptions(cfg)
+	report := comparator.PrepareComparisonData(candidate, baseline, candidateName, baselineName, opts)
+
+	result, err := checker.Check(report, checkConfig, cfg.Timeout)
+	if err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+
+	printCheckResult(result)
+
+	suite := junit.FromComparison(result.Levels, candidateName, baselineName)
+	if err := writeJUnit(fmt.Sprintf("%s vs %s", candidateName, baselineName), suite); err != nil {
+		fmt.Printf(colorRed+"Error writing JUnit report: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+
+	return result.Passed()
+}
+
+// loadCheckRules reads a rules file with the same layout as the Check
+// section of masbench_config.yml
+func loadCheckRules(path string) (models.CheckConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.CheckConfig{}, fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	var checkConfig models.CheckConfig
+	if err := yaml.Unmarshal(data, &checkConfig); err != nil {
+		return models.CheckConfig{}, fmt.Errorf("failed to parse rules file: %w", err)
+	}
+	if len(checkConfig.Rules) == 0 {
+		checkConfig.Rules = models.DefaultConfiguration.Check.Rules
+	}
+
+	return checkConfig, nil
+}
+
+func printCheckResult(result checker.Result) {
+	fmt.Printf("Checking %s against %s\n", result.Candidate, result.Baseline)
+	if len(result.Allowed) > 0 {
+		fmt.Printf("Allowlisted levels: %s\n", strings.Join(result.Allowed, ", "))
+	}
+	fmt.Println()
+
+	for _, rule := range result.Rules {
+		if rule.Reason != "" {
+			fmt.Printf(colorRed+"  ✗ %s%s (not evaluable: %s)\n", rule.Rule, colorReset, rule.Reason)
+			continue
+		}
+		if rule.Passed {
+			fmt.Printf(colorGreen+"  ✓ %s%s (%s)\n", rule.Rule, colorReset, formatRuleValues(rule))
+		} else {
+			fmt.Printf(colorRed+"  ✗ %s%s (%s)\n", rule.Rule, colorReset, formatRuleValues(rule))
+		}
+	}
+	fmt.Println()
+
+	violations := result.Violations()
+	if len(violations) == 0 {
+		fmt.Printf(colorGreen+"Check passed: all %d rules satisfied%s\n", len(result.Rules), colorReset)
+		return
+	}
+
+	fmt.Printf(colorRed+"Check failed: %d of %d rules violated%s\n", len(violations), len(result.Rules), colorReset)
+	for _, violation := range violations {
+		if violation.Reason != "" {
+			fmt.Printf("  - %s: not evaluable, %s\n", violation.Rule, violation.Reason)
+			continue
+		}
+		fmt.Printf("  - %s: got %s", violation.Rule, formatRuleValue(violation, violation.Value))
+		if len(violation.Levels) > 0 {
+			fmt.Printf(" (%s)", strings.Join(violation.Levels, ", "))
+		}
+		fmt.Println()
+	}
+}
+
+func formatRuleValues(rule checker.RuleResult) string {
+	return fmt.Sprintf("got %s, limit %s", formatRuleValue(rule, rule.Value), formatRuleValue(rule, rule.Limit))
+}
+
+func formatRuleValue(rule checker.RuleResult, value float64) string {
+	if rule.Count {
+		return fmt.Sprintf("%.0f", value)
+	}
+	return fmt.Sprintf("%.3f", value)
+}
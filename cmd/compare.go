@@ -2,21 +2,31 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/go-gota/gota/dataframe"
 	"github.com/spf13/cobra"
 	"masbench/internals/comparator"
 	"masbench/internals/config"
+	"masbench/internals/index"
+	"masbench/internals/models"
+	"masbench/internals/terminal"
 	"masbench/internals/utils"
 )
 
 func init() {
 	rootCmd.AddCommand(compareCmd)
+	addToleranceFlags(compareCmd)
+	addFormatFlag(compareCmd)
+	compareCmd.Flags().StringP("baseline", "b", "", "Compare every given benchmark against this baseline in a single report")
 }
 
 var compareCmd = &cobra.Command{
-	Use:   "compare <benchmark1> <benchmark2>",
+	Use:   "compare <benchmark1> <benchmark2> | --baseline <baseline> <candidate>...",
 	Short: "Compare two benchmark results and generate an interactive HTML report",
 	Long: `Compare two benchmark results and generate an interactive HTML report.
 
@@ -31,36 +41,118 @@ Examples:
   masbench compare astar-v1 bfs-v1
   masbench compare optimized-v2 baseline
 
-Note: Both benchmarks must exist in your configured benchmark folder.
-The generated HTML report can be opened directly in any web browser.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) < 2 {
-			fmt.Println(colorRed + "Error: You must provide two benchmark result files to compare." + colorReset)
-			os.Exit(1)
-		}
+To compare several candidates against the same baseline in one report, pass
+the baseline with --baseline. The report ranks the candidates by their net
+number of improvements over the baseline:
+  masbench compare --baseline base v1 v2 v3
+
+Changes within the configured tolerances are reported as unchanged. Override
+them per metric with --rel-tol and --abs-tol:
+  masbench compare optimized-v2 baseline --rel-tol Time=0.05 --abs-tol Time=0.2
+
+When both benchmarks were run with --repeat, a Mann-Whitney U test decides
+whether a difference is significant at the level given by --alpha, instead
+of the tolerances.
 
-		benchmark1Name := args[0]
-		Benchmark2Name := args[1]
+Use --format table to print the report in the terminal instead of writing
+an HTML file, e.g. over SSH:
+  masbench compare optimized-v2 baseline --format table
 
-		cfg := config.GetConfig()
+Use --format markdown to write a GitHub-flavored Markdown report instead
+of the HTML one, ready to be pasted in a pull request description, or
+--format json to export the full comparison for your own scripts.
 
-		benchmark1Path := filepath.Join(cfg.BenchmarkFolder, benchmark1Name, fmt.Sprintf("%s_results.csv", benchmark1Name))
-		benchmark2Path := filepath.Join(cfg.BenchmarkFolder, Benchmark2Name, fmt.Sprintf("%s_results.csv", Benchmark2Name))
+Use --output to write the report somewhere else, or --output - to print it
+to stdout:
+  masbench compare optimized-v2 baseline --format json --output - | jq .report.aggregates
 
-		if _, err := os.Stat(benchmark1Path); os.IsNotExist(err) {
-			fmt.Printf(colorRed+"Error: Benchmark result file not found: %s%s", benchmark1Name, colorReset)
+The benchmarks can be given as selectors, e.g. the last two runs:
+  masbench compare latest latest~1
+  masbench compare --baseline main 'feature-*'
+
+` + selectorHelp + `
+
+Note: Both benchmarks must exist in your configured benchmark folder.
+The generated HTML report can be opened directly in any web browser. Its
+styles and scripts are embedded in the file, so it also works offline.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		validateFormatOrExit()
+
+		baselineName, err := cmd.Flags().GetString("baseline")
+		if err != nil {
+			fmt.Println("failed to read flag:", err)
 			os.Exit(1)
 		}
 
-		if _, err := os.Stat(benchmark2Path); os.IsNotExist(err) {
-			fmt.Printf(colorRed+"Error: Benchmark result file not found: %s%s", Benchmark2Name, colorReset)
+		if baselineName != "" {
+			if len(args) < 1 {
+				fmt.Println(colorRed + "Error: You must provide at least one benchmark to compare against the baseline." + colorReset)
+				os.Exit(1)
+			}
+			baselineName = selectBenchmark(baselineName)
+			candidateNames := slices.DeleteFunc(selectBenchmarks(args), func(name string) bool { return name == baselineName })
+			switch len(candidateNames) {
+			case 0:
+				fmt.Println(colorRed + "Error: The benchmarks to compare only select the baseline." + colorReset)
+				os.Exit(1)
+			case 1:
+				compareResults(resultsPathOrExit(candidateNames[0]), resultsPathOrExit(baselineName), candidateNames[0], baselineName)
+			default:
+				compareAgainstBaseline(baselineName, candidateNames)
+			}
+			return
+		}
+
+		var benchmarkNames []string
+		switch len(args) {
+		case 1:
+			benchmarkNames = selectBenchmarks(args)
+			if len(benchmarkNames) != 2 {
+				fmt.Printf(colorRed+"Error: %s selects %d benchmarks, expected two.%s\n", args[0], len(benchmarkNames), colorReset)
+				os.Exit(1)
+			}
+		case 2:
+			benchmarkNames = []string{selectBenchmark(args[0]), selectBenchmark(args[1])}
+		}
+		if len(benchmarkNames) != 2 {
+			fmt.Println(colorRed + "Error: You must provide two benchmark result files to compare." + colorReset)
 			os.Exit(1)
 		}
 
-		compareResults(benchmark1Path, benchmark2Path, benchmark1Name, Benchmark2Name)
+		benchmark1Name := benchmarkNames[0]
+		Benchmark2Name := benchmarkNames[1]
+
+		compareResults(resultsPathOrExit(benchmark1Name), resultsPathOrExit(Benchmark2Name), benchmark1Name, Benchmark2Name)
 	},
 }
 
+// resultsPathOrExit returns the results CSV path of a benchmark, exiting
+// if the benchmark has no results
+func resultsPathOrExit(name string) string {
+	cfg := config.GetConfig()
+	path := filepath.Join(cfg.BenchmarkFolder, name, fmt.Sprintf("%s_results.csv", name))
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		fmt.Printf(colorRed+"Error: Benchmark result file not found: %s%s\n", name, colorReset)
+		os.Exit(1)
+	}
+	return path
+}
+
+// comparisonOptions builds the comparator options from the configuration
+// and the tolerance flags, exiting if the flags are invalid
+func comparisonOptions(cfg *models.Config) comparator.Options {
+	if err := applyToleranceFlags(cfg); err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+
+	return comparator.Options{
+		Tolerances:        cfg.Tolerances,
+		SignificanceLevel: cfg.SignificanceLevel,
+		Timeout:           cfg.Timeout,
+	}
+}
+
 func compareResults(benchmark1Path, benchmark2Path, name1, name2 string) {
 	df1, err := utils.LoadCSV(benchmark1Path)
 	if err != nil {
@@ -75,22 +167,107 @@ func compareResults(benchmark1Path, benchmark2Path, name1, name2 string) {
 	}
 
 	cfg := config.GetConfig()
-	outputDir := filepath.Join(cfg.BenchmarkFolder, "comparisons", fmt.Sprintf("%svs%s", name1, name2))
+	opts := comparisonOptions(cfg)
+	report := comparator.PrepareComparisonData(df1, df2, name1, name2, opts)
 
-	// Create output directory
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		fmt.Printf(colorRed+"Error creating output directory: %v%s\n", err, colorReset)
-		os.Exit(1)
+	benchmarks := []string{name1, name2}
+	comparisonName := comparisonFolderName(benchmarks)
+	outputDir := filepath.Join(cfg.BenchmarkFolder, "comparisons", comparisonName)
+	reportPath := writeReport(outputDir, comparisonName+"_report", func(w io.Writer) error {
+		return writeComparison(w, outputFormat, report)
+	})
+	printReportPath("Comparison", reportPath)
+	warnMissingScripts(reportPath, "chart.js", "html2canvas")
+	recordReport(reportPath, index.KindComparison, benchmarks, comparisonHeadline(report))
+}
+
+// writeComparison writes a comparison of two benchmarks in the given format
+func writeComparison(w io.Writer, format string, report comparator.ComparisonReport) error {
+	switch format {
+	case formatTable:
+		return comparator.WriteTable(w, report, terminal.Width(), reportColor())
+	case formatMarkdown:
+		return comparator.WriteMarkdown(w, report)
+	case formatJSON:
+		return comparator.WriteJSON(w, report)
+	default:
+		return comparator.WriteHTML(w, report)
 	}
+}
 
-	// Generate HTML report
-	reportPath := filepath.Join(outputDir, fmt.Sprintf("%svs%s_report.html", name1, name2))
-	if err := comparator.GenerateHTMLReport(df1, df2, name1, name2, reportPath); err != nil {
-		fmt.Printf(colorRed+"Error creating HTML report: %v%s\n", err, colorReset)
+func compareAgainstBaseline(baselineName string, candidateNames []string) {
+	baseline, err := utils.LoadCSV(resultsPathOrExit(baselineName))
+	if err != nil {
+		fmt.Printf(colorRed+"Error reading baseline CSV: %v%s\n", err, colorReset)
 		os.Exit(1)
 	}
 
-	fmt.Printf(colorGreen+"Comparison completed successfully!%s\n", colorReset)
-	fmt.Printf(colorGreen+"HTML Report: %s%s\n", reportPath, colorReset)
-	fmt.Printf(colorYellow+"Open the HTML file in your browser to view the interactive report.%s\n", colorReset)
+	candidates := make([]dataframe.DataFrame, 0, len(candidateNames))
+	for _, name := range candidateNames {
+		df, err := utils.LoadCSV(resultsPathOrExit(name))
+		if err != nil {
+			fmt.Printf(colorRed+"Error reading %s CSV: %v%s\n", name, err, colorReset)
+			os.Exit(1)
+		}
+		candidates = append(candidates, df)
+	}
+
+	cfg := config.GetConfig()
+	opts := comparisonOptions(cfg)
+	report := comparator.PrepareMultiComparisonData(baseline, baselineName, candidates, candidateNames, opts)
+
+	benchmarks := append([]string{baselineName}, candidateNames...)
+	comparisonName := comparisonFolderName(benchmarks)
+	outputDir := filepath.Join(cfg.BenchmarkFolder, "comparisons", comparisonName)
+	reportPath := writeReport(outputDir, comparisonName+"_report", func(w io.Writer) error {
+		return writeMultiComparison(w, outputFormat, report)
+	})
+	printReportPath("Comparison", reportPath)
+	recordReport(reportPath, index.KindComparison, benchmarks, multiComparisonHeadline(report))
+}
+
+// writeMultiComparison writes a comparison against a baseline in the given
+// format
+func writeMultiComparison(w io.Writer, format string, report comparator.MultiComparisonReport) error {
+	switch format {
+	case formatTable:
+		return comparator.WriteMultiTable(w, report, terminal.Width(), reportColor())
+	case formatMarkdown:
+		return comparator.WriteMultiMarkdown(w, report)
+	case formatJSON:
+		return comparator.WriteMultiJSON(w, report)
+	default:
+		return comparator.WriteMultiHTML(w, report)
+	}
+}
+
+// comparisonFolderName names a comparison after its benchmarks, in the order
+// they are recorded in the index: a pair compares the first against the
+// second, a1vsa2, and longer lists the candidates against the baseline
+// first, b1+b2vsa
+func comparisonFolderName(benchmarks []string) string {
+	if len(benchmarks) == 2 {
+		return fmt.Sprintf("%svs%s", benchmarks[0], benchmarks[1])
+	}
+	return fmt.Sprintf("%svs%s", strings.Join(benchmarks[1:], "+"), benchmarks[0])
+}
+
+// comparisonHeadline describes a comparison for the index
+func comparisonHeadline(report comparator.ComparisonReport) string {
+	headline := fmt.Sprintf("PAR-2 ratio %.2f", report.Aggregates.PAR2Ratio)
+	for _, metric := range report.Aggregates.Metrics {
+		if metric.Metric == models.ColTime && metric.RatioLevels > 0 {
+			headline += fmt.Sprintf(", time speedup %.2f×", metric.GeoMeanSpeedup)
+		}
+	}
+	return headline
+}
+
+// multiComparisonHeadline names the best candidate for the index
+func multiComparisonHeadline(report comparator.MultiComparisonReport) string {
+	if len(report.Ranking) == 0 {
+		return ""
+	}
+	best := report.Ranking[0]
+	return fmt.Sprintf("Best candidate %s, net %+d metric changes", best.Name, best.Net)
 }
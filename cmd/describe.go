@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"masbench/internals/config"
+	"masbench/internals/index"
+)
+
+var describeMessage string
+
+func init() {
+	rootCmd.AddCommand(describeCmd)
+	describeCmd.Flags().StringVarP(&describeMessage, "message", "m", "", "Replace the notes with this message instead of opening an editor")
+}
+
+var describeCmd = &cobra.Command{
+	Use:   "describe <benchmark>",
+	Short: "Edit the notes of a benchmark",
+	Long: `Edit the notes of a benchmark, the message given to masbench run -m and
+saved in <name>.md. They are shown by masbench list, in the reports index
+and in the dashboard.
+
+Without --message the notes are opened in the editor of $VISUAL or $EDITOR,
+or vi (notepad on Windows) when neither is set.
+
+Examples:
+  masbench describe astar-v3
+  masbench describe latest -m "A* with the goal count heuristic"`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := selectBenchmark(args[0])
+		cfg := config.GetConfig()
+		path := filepath.Join(cfg.BenchmarkFolder, name, name+".md")
+
+		if cmd.Flags().Changed("message") {
+			if err := os.WriteFile(path, []byte(describeMessage+"\n"), 0644); err != nil {
+				fmt.Printf(colorRed+"Error: failed to write %s: %v%s\n", path, err, colorReset)
+				os.Exit(1)
+			}
+		} else if err := editFile(path); err != nil {
+			fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+			os.Exit(1)
+		}
+
+		fmt.Printf(colorGreen+"%s: %s%s\n", name, index.Description(cfg.BenchmarkFolder, name), colorReset)
+		refreshIndex()
+	},
+}
+
+// editFile opens a file in the editor of the user and waits for it to exit
+func editFile(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	// The editor may come with arguments, e.g. "code --wait"
+	fields := strings.Fields(editor)
+	cmd := exec.Command(fields[0], append(fields[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run %s: %w", editor, err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"masbench/internals/assets"
+	"masbench/internals/terminal"
+)
+
+// Report output formats
+const (
+	formatHTML     = "html"
+	formatTable    = "table"
+	formatMarkdown = "markdown"
+	formatJSON     = "json"
+)
+
+var reportFormats = []string{formatHTML, formatTable, formatMarkdown, formatJSON}
+
+// reportExtensions is the file extension of every format written to a file
+// by default, the table format goes to stdout
+var reportExtensions = map[string]string{
+	formatHTML:     ".html",
+	formatMarkdown: ".md",
+	formatJSON:     ".json",
+}
+
+// stdoutOutput is the --output value writing the report to stdout
+const stdoutOutput = "-"
+
+var outputFormat string
+var outputPath string
+
+// addFormatFlag registers the --format and --output flags of the report commands
+func addFormatFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&outputFormat, "format", "f", formatHTML, "Output format: "+strings.Join(reportFormats, ", "))
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "Write the report to this file instead of the default location, - for stdout")
+}
+
+// validateFormatOrExit exits if --format is not a known format
+func validateFormatOrExit() {
+	for _, format := range reportFormats {
+		if outputFormat == format {
+			return
+		}
+	}
+	fmt.Printf(colorRed+"Error: unknown format %q, expected one of %s%s\n", outputFormat, strings.Join(reportFormats, ", "), colorReset)
+	os.Exit(1)
+}
+
+// reportColor reports whether a terminal table should be colored, only
+// when it is printed to a terminal
+func reportColor() bool {
+	return (outputPath == "" || outputPath == stdoutOutput) && terminal.ColorEnabled()
+}
+
+// writeReport writes a report with write to the --output path, to stdout,
+// or by default to dir/baseName with the extension of the format. It returns
+// the path of the written file, empty when the report went to stdout
+func writeReport(dir, baseName string, write func(io.Writer) error) string {
+	path := outputPath
+	if path == "" {
+		extension, ok := reportExtensions[outputFormat]
+		if !ok {
+			path = stdoutOutput
+		} else {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				fmt.Printf(colorRed+"Error creating output directory: %v%s\n", err, colorReset)
+				os.Exit(1)
+			}
+			path = filepath.Join(dir, baseName+extension)
+		}
+	}
+
+	if path == stdoutOutput {
+		if err := write(os.Stdout); err != nil {
+			fmt.Printf(colorRed+"Error writing report: %v%s\n", err, colorReset)
+			os.Exit(1)
+		}
+		return ""
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Printf(colorRed+"Error creating report file: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	if err := write(file); err != nil {
+		fmt.Printf(colorRed+"Error writing report: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+	return path
+}
+
+// warnMissingScripts warns when an HTML report written to path uses scripts
+// that are not embedded in this build
+func warnMissingScripts(path string, scripts ...string) {
+	if path == "" || outputFormat != formatHTML {
+		return
+	}
+
+	var names []string
+	for _, script := range assets.Missing() {
+		if slices.Contains(scripts, script.Name) {
+			names = append(names, script.Name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+	fmt.Printf(colorYellow+"Warning: scripts not embedded in this build (%s), the report loads them from a CDN and needs internet access.%s\n", strings.Join(names, ", "), colorReset)
+}
+
+// printReportPath tells where a report was written, if it went to a file
+func printReportPath(title, path string) {
+	if path == "" {
+		return
+	}
+
+	fmt.Printf(colorGreen+"%s completed successfully!%s\n", title, colorReset)
+	switch outputFormat {
+	case formatHTML:
+		fmt.Printf(colorGreen+"HTML Report: %s%s\n", path, colorReset)
+		fmt.Printf(colorYellow+"Open the HTML file in your browser to view the interactive report.%s\n", colorReset)
+	case formatMarkdown:
+		fmt.Printf(colorGreen+"Markdown Report: %s%s\n", path, colorReset)
+	case formatJSON:
+		fmt.Printf(colorGreen+"JSON Report: %s%s\n", path, colorReset)
+	default:
+		fmt.Printf(colorGreen+"Report: %s%s\n", path, colorReset)
+	}
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"masbench/internals/config"
+	"masbench/internals/index"
+)
+
+// recordReport adds a report written to path to the index of the benchmark
+// folder and regenerates the index page. Reports printed to stdout have no
+// path and are not recorded
+func recordReport(path, kind string, benchmarks []string, headline string) {
+	if path != "" {
+		cfg := config.GetConfig()
+		report := index.Report{Kind: kind, Benchmarks: benchmarks, CreatedAt: time.Now(), Headline: headline}
+		if err := index.RecordReport(cfg.BenchmarkFolder, path, report); err != nil {
+			fmt.Printf(colorYellow+"Warning: could not record the report in the index: %v%s\n", err, colorReset)
+		}
+	}
+	refreshIndex()
+}
+
+// migrateReportFolders marks the report folders written by older versions,
+// so that commands changing benchmarks never select them
+func migrateReportFolders() {
+	cfg := config.GetConfig()
+	if err := index.MarkLegacyReportFolders(cfg.BenchmarkFolder); err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+}
+
+// refreshIndex regenerates the index page of the benchmark folder
+func refreshIndex() {
+	cfg := config.GetConfig()
+	if err := index.Generate(cfg.BenchmarkFolder); err != nil {
+		fmt.Printf(colorYellow+"Warning: could not update %s: %v%s\n", index.IndexFile, err, colorReset)
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"masbench/internals/junit"
+)
+
+var junitPath string
+
+// addJUnitFlag registers the --junit flag of the commands producing test results
+func addJUnitFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&junitPath, "junit", "", "Also write the results as JUnit XML to this file, one testcase per level")
+}
+
+// writeJUnit writes the suites to the --junit path, if one was given
+func writeJUnit(name string, suites ...junit.TestSuite) error {
+	if junitPath == "" {
+		return nil
+	}
+
+	file, err := os.Create(junitPath)
+	if err != nil {
+		return fmt.Errorf("failed to create JUnit report: %w", err)
+	}
+	defer file.Close()
+
+	if err := junit.Write(file, name, suites...); err != nil {
+		return err
+	}
+
+	fmt.Printf(colorGreen+"JUnit report written to %s%s\n", junitPath, colorReset)
+	return nil
+}
@@ -1,71 +1,254 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"masbench/internals/config"
+	"masbench/internals/index"
+	"masbench/internals/terminal"
 
 	"github.com/spf13/cobra"
 )
 
+// Orders of masbench list --sort
+const (
+	sortByName    = "name"
+	sortByDate    = "date"
+	sortBySolved  = "solved"
+	sortByTime    = "time"
+	sortByActions = "actions"
+)
+
+var listSortOrders = []string{sortByName, sortByDate, sortBySolved, sortByTime, sortByActions}
+
+var listTags []string
+var listSort string
+var listLong bool
+var listJSON bool
+
 func init() {
 	rootCmd.AddCommand(listCmd)
 	listCmd.Flags().BoolP("name-only", "n", false, "Show only benchrun names, hide descriptions")
+	listCmd.Flags().StringSliceVar(&listTags, "tag", nil, "Only list the benchmarks with this tag, can be repeated")
+	listCmd.Flags().StringVar(&listSort, "sort", sortByName, "Order of the benchmarks: name, date (most recent first), solved (most first), time or actions (least first)")
+	listCmd.Flags().BoolVarP(&listLong, "long", "l", false, "Show the tags, date, algorithm, solved levels, total time and total actions of every benchmark")
+	listCmd.Flags().BoolVar(&listJSON, "json", false, "Print the benchmarks and their headline numbers as JSON")
 }
 
 var listCmd = &cobra.Command{
 	Short: "list all benchmarks",
 	Use:   "list",
+	Long: `List the benchmarks of the benchmark folder with their descriptions.
+
+With --long, every benchmark is shown with its tags, the date it was run,
+its algorithm, the levels it solved and its total time and actions over the
+solved levels, computed from its results. With repeated runs a level is
+solved when the majority of its runs are, and its values are the medians.
+
+Use --json to print the same numbers as JSON for your own scripts.
+
+Folders holding reports, such as comparisons and summaries, are not listed.
+masbench marks them with a ` + index.ReportsMarker + ` file.
+
+Examples:
+  masbench list
+  masbench list --tag nightly --sort date --long
+  masbench list --sort solved --json`,
 	Run: func(cmd *cobra.Command, args []string) {
 		nameOnly, err := cmd.Flags().GetBool("name-only")
 		if err != nil {
 			fmt.Println("failed to read flag:", err)
 			return
 		}
+		if !slices.Contains(listSortOrders, listSort) {
+			fmt.Printf(colorRed+"Error: invalid sort order %q, expected one of %s%s\n", listSort, strings.Join(listSortOrders, ", "), colorReset)
+			os.Exit(1)
+		}
+		if listJSON && (listLong || nameOnly) {
+			fmt.Println(colorRed + "Error: --json cannot be combined with --long or --name-only." + colorReset)
+			os.Exit(1)
+		}
 		list(nameOnly)
 	},
 }
 
 func list(nameOnly bool) {
 	cfg := config.GetConfig()
-	entries, err := os.ReadDir(cfg.BenchmarkFolder)
+	names, err := index.BenchmarkFolders(cfg.BenchmarkFolder)
 	if err != nil {
-		fmt.Printf("failed to read directory %s: %s\n", cfg.BenchmarkFolder, err.Error())
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
 	}
 
-	for _, entry := range entries {
-		entryName := entry.Name()
-		if !entry.IsDir() {
+	var benchmarks []index.Benchmark
+	for _, name := range names {
+		// Benchmarks without results, e.g. interrupted runs, are listed
+		// with their description only
+		benchmark, err := index.LoadBenchmark(cfg.BenchmarkFolder, name)
+		if err != nil {
+			benchmark = index.Benchmark{Name: name, Description: index.Description(cfg.BenchmarkFolder, name)}
+		}
+		if !hasTags(benchmark.Tags, listTags) {
 			continue
 		}
+		benchmarks = append(benchmarks, benchmark)
+	}
+	sortBenchmarks(benchmarks, listSort)
 
-		// TODO: find a better way rather than hardcoding
-		if entryName == "comparisons" {
-			continue
+	switch {
+	case listJSON:
+		err = listJSONOutput(benchmarks)
+	case listLong:
+		err = listTable(benchmarks)
+	default:
+		for _, benchmark := range benchmarks {
+			if nameOnly || benchmark.Description == "" {
+				fmt.Println(benchmark.Name)
+				continue
+			}
+			fmt.Printf("%s: %s\n", benchmark.Name, benchmark.Description)
 		}
+	}
+	if err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+}
 
-		// TODO: find a better way rather than hardcoding
-		if entryName == "summaries" {
-			continue
+// sortBenchmarks orders the benchmarks, already sorted by name, by the given
+// order. Benchmarks without results come last
+func sortBenchmarks(benchmarks []index.Benchmark, order string) {
+	if order == sortByName {
+		return
+	}
+	slices.SortStableFunc(benchmarks, func(a, b index.Benchmark) int {
+		if hasResults(a) != hasResults(b) {
+			if hasResults(a) {
+				return -1
+			}
+			return 1
 		}
+		switch order {
+		case sortByDate:
+			return b.StartedAt.Compare(a.StartedAt)
+		case sortBySolved:
+			return b.LevelsSolved - a.LevelsSolved
+		case sortByTime:
+			return compareFloats(a.TotalTime, b.TotalTime)
+		default:
+			return compareFloats(a.TotalActions, b.TotalActions)
+		}
+	})
+}
 
-		if nameOnly {
-			fmt.Println(entry.Name())
-			continue
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func hasResults(benchmark index.Benchmark) bool {
+	return benchmark.ResultsPath != ""
+}
+
+// listTable prints the benchmarks as a table, the description is dropped
+// first when the terminal is too narrow
+func listTable(benchmarks []index.Benchmark) error {
+	table := terminal.NewTable(
+		terminal.Column{Header: "Benchmark"},
+		terminal.Column{Header: "Tags"},
+		terminal.Column{Header: "Date"},
+		terminal.Column{Header: "Algorithm"},
+		terminal.Column{Header: "Solved", AlignRight: true},
+		terminal.Column{Header: "Total time", AlignRight: true},
+		terminal.Column{Header: "Total actions", AlignRight: true, Optional: true},
+		terminal.Column{Header: "Description", Optional: true},
+	)
+	for _, benchmark := range benchmarks {
+		date, solved, totalTime, totalActions := "-", "-", "-", "-"
+		if hasResults(benchmark) {
+			date = benchmark.StartedAt.Format("2006-01-02 15:04")
+			solved = fmt.Sprintf("%d/%d", benchmark.LevelsSolved, benchmark.LevelsTotal)
+			totalTime = fmt.Sprintf("%.2fs", benchmark.TotalTime)
+			totalActions = strconv.FormatFloat(benchmark.TotalActions, 'f', -1, 64)
 		}
+		algorithm := benchmark.Algorithm
+		if algorithm == "" {
+			algorithm = "-"
+		}
+		description, _, _ := strings.Cut(benchmark.Description, "\n")
+		table.AddRow(
+			terminal.Colored(benchmark.Name, terminal.ColorBlue),
+			terminal.Text(formatTags(benchmark.Tags)),
+			terminal.Text(date),
+			terminal.Text(algorithm),
+			terminal.Text(solved),
+			terminal.Text(totalTime),
+			terminal.Text(totalActions),
+			terminal.Text(description),
+		)
+	}
+	return table.Render(os.Stdout, terminal.Width(), terminal.ColorEnabled())
+}
 
-		descriptionFilePath := filepath.Join(cfg.BenchmarkFolder, entryName, entryName+".md")
-		descriptionBytes, err := os.ReadFile(descriptionFilePath)
+// listedBenchmark is a benchmark in the output of list --json, benchmarks
+// without results only have a name and a description
+type listedBenchmark struct {
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	Tags         []string   `json:"tags"`
+	Algorithm    string     `json:"algorithm,omitempty"`
+	StartedAt    *time.Time `json:"startedAt,omitempty"`
+	LevelsSolved int        `json:"levelsSolved"`
+	LevelsTotal  int        `json:"levelsTotal"`
+	TotalTime    float64    `json:"totalTime"`
+	TotalActions float64    `json:"totalActions"`
+	Results      string     `json:"results,omitempty"`
+}
 
-		if err != nil || len(descriptionBytes) == 0 {
-			fmt.Println(entryName)
-			continue
+func listJSONOutput(benchmarks []index.Benchmark) error {
+	listed := make([]listedBenchmark, len(benchmarks))
+	for i, benchmark := range benchmarks {
+		listed[i] = listedBenchmark{
+			Name:         benchmark.Name,
+			Description:  benchmark.Description,
+			Tags:         benchmark.Tags,
+			Algorithm:    benchmark.Algorithm,
+			LevelsSolved: benchmark.LevelsSolved,
+			LevelsTotal:  benchmark.LevelsTotal,
+			TotalTime:    benchmark.TotalTime,
+			TotalActions: benchmark.TotalActions,
+			Results:      benchmark.ResultsPath,
+		}
+		if listed[i].Tags == nil {
+			listed[i].Tags = []string{}
+		}
+		if hasResults(benchmark) {
+			listed[i].StartedAt = &benchmark.StartedAt
 		}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(listed)
+}
 
-		description := strings.TrimSpace(string(descriptionBytes))
-		fmt.Printf("%s: %s\n", entryName, description)
+// hasTags reports whether a benchmark has every one of the wanted tags
+func hasTags(tags, wanted []string) bool {
+	for _, tag := range wanted {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
 	}
+	return true
 }
@@ -0,0 +1,408 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-gota/gota/dataframe"
+	"github.com/spf13/cobra"
+	"masbench/internals/comparator"
+	"masbench/internals/config"
+	"masbench/internals/index"
+	"masbench/internals/summarizer"
+	"masbench/internals/trend"
+	"masbench/internals/utils"
+)
+
+func init() {
+	rootCmd.AddCommand(mvCmd)
+	rootCmd.AddCommand(cpCmd)
+}
+
+var mvCmd = &cobra.Command{
+	Use:   "mv <benchmark> <new-name>",
+	Short: "Rename a benchmark and update the reports covering it",
+	Long: `Rename a benchmark, e.g. to fix a typo. The folder and every file named
+after the benchmark, such as <name>_results.csv, <name>.md and the logs,
+are renamed.
+
+The comparisons, summaries and trends covering the benchmark are rebuilt
+from the results with the new name, with the current configuration. Those
+written to their default location move with the name, e.g.
+comparisons/oldvsbase/ becomes comparisons/newvsbase/. Only reports inside
+the benchmark folder are rebuilt, and nothing is renamed if one of them
+cannot be rebuilt.
+
+masbench refuses to overwrite an existing benchmark.
+
+Examples:
+  masbench mv astar-v1-tpyo astar-v1
+  masbench mv latest astar-v4`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		migrateReportFolders()
+		oldName := selectBenchmarkFolder(args[0])
+		if err := renameBenchmark(oldName, args[1], comparisonOptions(config.GetConfig())); err != nil {
+			fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+			os.Exit(1)
+		}
+		fmt.Printf(colorGreen+"Renamed %s to %s%s\n", oldName, args[1], colorReset)
+	},
+}
+
+var cpCmd = &cobra.Command{
+	Use:   "cp <benchmark> <new-name>",
+	Short: "Copy a benchmark under a new name",
+	Long: `Copy a benchmark under a new name, e.g. to keep a run as a baseline. The
+files named after the benchmark are renamed in the copy, and its tags and
+notes are copied. Reports are not copied.
+
+masbench refuses to overwrite an existing benchmark.
+
+Examples:
+  masbench cp astar-v3 baseline`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		migrateReportFolders()
+		name := selectBenchmarkFolder(args[0])
+		if err := copyBenchmark(name, args[1]); err != nil {
+			fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+			os.Exit(1)
+		}
+		fmt.Printf(colorGreen+"Copied %s to %s%s\n", name, args[1], colorReset)
+	},
+}
+
+// validBenchmarkName reports whether name is a plain folder name that
+// selectors cannot mistake for a glob
+func validBenchmarkName(name string) bool {
+	return name != "" && name == filepath.Base(name) && !strings.HasPrefix(name, ".") && !strings.ContainsAny(name, "*?[:")
+}
+
+// checkNewBenchmarkName returns an error if name cannot be used for a new
+// benchmark: it must be valid, and no benchmark or report folder may have it
+func checkNewBenchmarkName(name string) error {
+	if !validBenchmarkName(name) {
+		return fmt.Errorf("invalid benchmark name %q", name)
+	}
+	if _, err := os.Stat(filepath.Join(config.GetConfig().BenchmarkFolder, name)); !os.IsNotExist(err) {
+		return fmt.Errorf("a benchmark called %s already exists", name)
+	}
+	return nil
+}
+
+// renameBenchmark renames a benchmark and the files named after it, then
+// rebuilds the reports covering it with the new name. Comparisons are
+// rebuilt with opts
+func renameBenchmark(oldName, newName string, opts comparator.Options) error {
+	cfg := config.GetConfig()
+	benchFolder := cfg.BenchmarkFolder
+
+	if err := checkBenchmarkExists(oldName); err != nil {
+		return err
+	}
+	if err := checkNewBenchmarkName(newName); err != nil {
+		return err
+	}
+	dependents, err := index.Dependents(benchFolder, oldName)
+	if err != nil {
+		return err
+	}
+	// Nothing is renamed unless every report can be rebuilt
+	for _, report := range dependents {
+		reportPath := filepath.Join(benchFolder, filepath.FromSlash(report.Path))
+		if _, _, err := prepareReport(reportPath, report, opts); err != nil {
+			return fmt.Errorf("cannot rebuild %s: %w", report.Path, err)
+		}
+	}
+
+	// Rename the files named after the benchmark, then the folder itself
+	oldPath := filepath.Join(benchFolder, oldName)
+	if err := renameBenchmarkFiles(oldPath, oldName, newName); err != nil {
+		return err
+	}
+	if err := os.Rename(oldPath, filepath.Join(benchFolder, newName)); err != nil {
+		return fmt.Errorf("failed to rename %s: %w", oldName, err)
+	}
+	defer refreshIndex()
+
+	if err := renameInMetadata(newName); err != nil {
+		return err
+	}
+	renameInReports(dependents, oldName, newName, opts)
+	return nil
+}
+
+// renameBenchmarkFiles renames the files named after a benchmark in its
+// folder, dir
+func renameBenchmarkFiles(dir, oldName, newName string) error {
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !isNamedAfter(entry.Name(), oldName) {
+			return err
+		}
+		renamed := filepath.Join(filepath.Dir(path), newName+strings.TrimPrefix(entry.Name(), oldName))
+		return os.Rename(path, renamed)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rename the files of %s: %w", oldName, err)
+	}
+	return nil
+}
+
+// copyBenchmark copies a benchmark under a new name, renaming the files named
+// after it. Modification times are kept, they date benchmarks without
+// metadata
+func copyBenchmark(name, newName string) error {
+	cfg := config.GetConfig()
+	benchFolder := cfg.BenchmarkFolder
+
+	if err := checkBenchmarkExists(name); err != nil {
+		return err
+	}
+	if err := checkNewBenchmarkName(newName); err != nil {
+		return err
+	}
+
+	source := filepath.Join(benchFolder, name)
+	err := filepath.WalkDir(source, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		if isNamedAfter(entry.Name(), name) {
+			relative = filepath.Join(filepath.Dir(relative), newName+strings.TrimPrefix(entry.Name(), name))
+		}
+		destination := filepath.Join(benchFolder, newName, relative)
+
+		if entry.IsDir() {
+			return os.MkdirAll(destination, 0755)
+		}
+		return copyFile(path, destination)
+	})
+	if err != nil {
+		os.RemoveAll(filepath.Join(benchFolder, newName))
+		return fmt.Errorf("failed to copy %s: %w", name, err)
+	}
+	defer refreshIndex()
+
+	return renameInMetadata(newName)
+}
+
+// copyFile copies a file with its permissions and modification time
+func copyFile(source, destination string) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(destination, os.O_CREATE|os.O_EXCL|os.O_WRONLY, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Chtimes(destination, info.ModTime(), info.ModTime())
+}
+
+// renameInMetadata records the new name of a renamed or copied benchmark in
+// its metadata, if it has any
+func renameInMetadata(name string) error {
+	cfg := config.GetConfig()
+	if _, err := os.Stat(utils.MetadataPath(cfg.BenchmarkFolder, name)); err != nil {
+		return nil
+	}
+	meta, err := utils.LoadMetadata(cfg.BenchmarkFolder, name)
+	if err != nil {
+		return err
+	}
+	meta.Name = name
+	return utils.WriteMetadata(cfg.BenchmarkFolder, meta)
+}
+
+// isNamedAfter reports whether a file of a benchmark is named after it, such
+// as name.md, name_results.csv or name_server.zip
+func isNamedAfter(fileName, name string) bool {
+	return strings.HasPrefix(fileName, name+"_") || strings.HasPrefix(fileName, name+".")
+}
+
+// renameInReports rebuilds the reports covering a renamed benchmark with its
+// new name. Reports at the default location of the old name move to the
+// default location of the new name, the others are rebuilt in place. The
+// benchmark is already renamed, so a report that cannot be rebuilt is left
+// as it is with a warning
+func renameInReports(reports []index.Report, oldName, newName string, opts comparator.Options) {
+	cfg := config.GetConfig()
+	for _, report := range reports {
+		oldPath, oldBenchmarks := report.Path, report.Benchmarks
+		report.Benchmarks = slices.Clone(oldBenchmarks)
+		for i, name := range report.Benchmarks {
+			if name == oldName {
+				report.Benchmarks[i] = newName
+			}
+		}
+		report.Path = renamedReportPath(report, oldPath, oldBenchmarks, oldName, newName)
+
+		reportPath := filepath.Join(cfg.BenchmarkFolder, filepath.FromSlash(report.Path))
+		headline, err := rebuildReport(reportPath, report, opts)
+		if err != nil {
+			fmt.Printf(colorYellow+"Warning: failed to rebuild %s: %v%s\n", oldPath, err, colorReset)
+			continue
+		}
+		report.Headline = headline
+		if err := index.RecordReport(cfg.BenchmarkFolder, reportPath, report); err != nil {
+			fmt.Printf(colorYellow+"Warning: %v%s\n", err, colorReset)
+		}
+
+		// The old report may be inside the renamed benchmark folder
+		if _, err := os.Stat(filepath.Join(cfg.BenchmarkFolder, filepath.FromSlash(oldPath))); err == nil && oldPath != report.Path {
+			if err := removeReport(oldPath); err != nil {
+				fmt.Printf(colorYellow+"Warning: %v%s\n", err, colorReset)
+			}
+		}
+		fmt.Printf("Rebuilt %s\n", report.Path)
+	}
+}
+
+// renamedReportPath returns where a report covering a renamed benchmark goes
+func renamedReportPath(report index.Report, oldPath string, oldBenchmarks []string, oldName, newName string) string {
+	if rest, ok := strings.CutPrefix(oldPath, oldName+"/"); ok {
+		return newName + "/" + rest
+	}
+
+	extension := path.Ext(oldPath)
+	switch report.Kind {
+	case index.KindComparison:
+		oldComparison := comparisonFolderName(oldBenchmarks)
+		if oldPath == path.Join("comparisons", oldComparison, oldComparison+"_report"+extension) {
+			comparison := comparisonFolderName(report.Benchmarks)
+			return path.Join("comparisons", comparison, comparison+"_report"+extension)
+		}
+	case index.KindSummary:
+		if oldPath == path.Join("summaries", defaultSummaryName(oldBenchmarks)+"_summary"+extension) {
+			return path.Join("summaries", defaultSummaryName(report.Benchmarks)+"_summary"+extension)
+		}
+	}
+	return oldPath
+}
+
+// rebuildReport writes a report again from the results of its benchmarks,
+// in the format of its extension, and returns its headline
+func rebuildReport(reportPath string, report index.Report, opts comparator.Options) (string, error) {
+	write, headline, err := prepareReport(reportPath, report, opts)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(reportPath), 0755); err != nil {
+		return "", err
+	}
+	file, err := os.Create(reportPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	return headline, write(file)
+}
+
+// prepareReport loads the results a report is built from, without writing
+// anything, and returns the function writing it and its headline
+func prepareReport(reportPath string, report index.Report, opts comparator.Options) (func(io.Writer) error, string, error) {
+	cfg := config.GetConfig()
+
+	format := ""
+	for f, extension := range reportExtensions {
+		if filepath.Ext(reportPath) == extension {
+			format = f
+		}
+	}
+	if format == "" || len(report.Benchmarks) == 0 {
+		return nil, "", fmt.Errorf("unknown report")
+	}
+
+	var write func(io.Writer) error
+	var headline string
+	switch report.Kind {
+	case index.KindComparison:
+		dataframes, err := loadResults(report.Benchmarks)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(report.Benchmarks) == 2 {
+			comparison := comparator.PrepareComparisonData(dataframes[0], dataframes[1], report.Benchmarks[0], report.Benchmarks[1], opts)
+			write = func(w io.Writer) error { return writeComparison(w, format, comparison) }
+			headline = comparisonHeadline(comparison)
+		} else {
+			comparison := comparator.PrepareMultiComparisonData(dataframes[0], report.Benchmarks[0], dataframes[1:], report.Benchmarks[1:], opts)
+			write = func(w io.Writer) error { return writeMultiComparison(w, format, comparison) }
+			headline = multiComparisonHeadline(comparison)
+		}
+	case index.KindSummary:
+		paths := make(map[string]string, len(report.Benchmarks))
+		for _, name := range report.Benchmarks {
+			paths[name] = filepath.Join(cfg.BenchmarkFolder, name, fmt.Sprintf("%s_results.csv", name))
+		}
+		summary, err := summarizer.PrepareSummaryData(paths)
+		if err != nil {
+			return nil, "", err
+		}
+		write = func(w io.Writer) error { return writeSummary(w, format, summary) }
+		headline = summaryHeadline(summary)
+	case index.KindTrend:
+		inputs, err := trendInputs(report.Benchmarks)
+		if err != nil {
+			return nil, "", err
+		}
+		series := trend.Compute(inputs, cfg.Timeout)
+		write = func(w io.Writer) error { return writeTrend(w, format, series) }
+		headline = trendHeadline(series)
+	default:
+		return nil, "", fmt.Errorf("unknown report kind %q", report.Kind)
+	}
+
+	return write, headline, nil
+}
+
+// loadResults loads the results of the benchmarks
+func loadResults(names []string) ([]dataframe.DataFrame, error) {
+	cfg := config.GetConfig()
+	dataframes := make([]dataframe.DataFrame, len(names))
+	for i, name := range names {
+		df, err := utils.LoadCSV(filepath.Join(cfg.BenchmarkFolder, name, fmt.Sprintf("%s_results.csv", name)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the results of %s: %w", name, err)
+		}
+		dataframes[i] = df
+	}
+	return dataframes, nil
+}
+
+// removeReport deletes a report, and the folder of its comparison if it is
+// left empty
+func removeReport(reportPath string) error {
+	cfg := config.GetConfig()
+	file := filepath.Join(cfg.BenchmarkFolder, filepath.FromSlash(reportPath))
+	if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", reportPath, err)
+	}
+	// Fails, as it should, while the folder holds other files
+	os.Remove(filepath.Dir(file))
+	return nil
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"masbench/internals/models"
+	"masbench/internals/plots"
+	"masbench/internals/utils"
+)
+
+var plotKind string
+var plotMetric string
+var plotOut string
+var plotLog bool
+
+func init() {
+	rootCmd.AddCommand(plotCmd)
+	plotCmd.Flags().StringVar(&plotKind, "kind", plots.KindBar, "Kind of plot: "+strings.Join(plots.Kinds, ", "))
+	plotCmd.Flags().StringVar(&plotMetric, "metric", "time", "Metric to plot: time, actions, generated, explored or memory")
+	plotCmd.Flags().StringVar(&plotOut, "out", "", "Output file, the format is chosen from the extension: "+strings.Join(plots.Extensions, ", "))
+	plotCmd.Flags().BoolVar(&plotLog, "log", false, "Use a logarithmic scale for the metric")
+	plotCmd.MarkFlagRequired("out")
+}
+
+var plotCmd = &cobra.Command{
+	Use:   "plot <benchmark1> [benchmark2] ... --out <file>",
+	Short: "Render benchmark results as a static figure (SVG, PNG or PDF)",
+	Long: `Render the results of one or more benchmarks as a static figure, ready to
+be included in a report or a paper.
+
+Kinds:
+  bar       the metric of every benchmark per level, as grouped bars
+  scatter   the metric per level of every benchmark against the first one,
+            points below the diagonal are levels where the benchmark does better
+  cactus    the number of levels solved within a budget of the metric, the
+            higher and further left the better
+  box       the distribution of the metric over the solved levels
+  profile   the Dolan-Moré performance profile: the fraction of levels solved
+            within a factor τ of the best benchmark on each level
+
+Only solved levels are plotted. With repeated runs a level is solved when
+the majority of its runs are, and its value is the median over the runs.
+
+Every benchmark keeps the same color across kinds, as long as the benchmarks
+are given in the same order. Use --log for metrics spanning several orders of
+magnitude, values of zero are then left out.
+
+` + selectorHelp + `
+
+Examples:
+  masbench plot astar-v1 bfs-v1 --kind cactus --metric time --log --out cactus.pdf
+  masbench plot astar-v1 bfs-v1 --kind scatter --metric explored --log --out explored.svg
+  masbench plot astar-v1 bfs-v1 greedy-v1 --kind box --metric actions --out actions.png
+  masbench plot astar-v1 bfs-v1 greedy-v1 --kind profile --metric time --out profile.pdf`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			fmt.Println(colorRed + "Error: You must provide at least one benchmark name." + colorReset)
+			os.Exit(1)
+		}
+
+		column, err := models.MetricColumn(plotMetric)
+		if err != nil {
+			fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+			os.Exit(1)
+		}
+
+		plotBenchmarks(selectBenchmarks(args), plots.Options{Kind: plotKind, Metric: column, Log: plotLog}, plotOut)
+	},
+}
+
+func plotBenchmarks(benchmarkNames []string, opts plots.Options, out string) {
+	series := make([]plots.Series, len(benchmarkNames))
+	for i, name := range benchmarkNames {
+		df, err := utils.LoadCSV(resultsPathOrExit(name))
+		if err != nil {
+			fmt.Printf(colorRed+"Error reading CSV of %s: %v%s\n", name, err, colorReset)
+			os.Exit(1)
+		}
+		series[i] = plots.LoadSeries(name, df, opts.Metric)
+	}
+
+	if err := plots.Render(series, opts, out); err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+
+	fmt.Printf(colorGreen+"Plot written to %s%s\n", out, colorReset)
+}
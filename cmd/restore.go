@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"masbench/internals/config"
+	"masbench/internals/index"
+	"masbench/internals/terminal"
+	"masbench/internals/trash"
+)
+
+var restoreList bool
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+	restoreCmd.Flags().BoolVarP(&restoreList, "list", "l", false, "List the removed benchmarks in the trash")
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <benchmark> | --list",
+	Short: "Restore a benchmark removed with masbench rm",
+	Long: `Restore a benchmark removed with masbench rm, with the reports removed
+along with it. When a benchmark was removed several times, the last removal
+is restored.
+
+A benchmark cannot be restored while a benchmark or a report with the same
+name exists, remove or rename it first.
+
+Examples:
+  masbench restore --list
+  masbench restore old-experiment`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.GetConfig()
+		entries, err := trash.List(cfg.BenchmarkFolder)
+		if err != nil {
+			fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+			os.Exit(1)
+		}
+
+		if restoreList {
+			listTrash(entries)
+			return
+		}
+		if len(args) != 1 {
+			fmt.Println(colorRed + "Error: You must provide the benchmark to restore, or --list." + colorReset)
+			os.Exit(1)
+		}
+
+		for _, entry := range entries {
+			if entry.Name == args[0] {
+				restore(entry)
+				return
+			}
+		}
+		fmt.Printf(colorRed+"Error: No removed benchmark called %s in the trash.%s\n", args[0], colorReset)
+		os.Exit(1)
+	},
+}
+
+func restore(entry trash.Entry) {
+	cfg := config.GetConfig()
+	if err := trash.Restore(cfg.BenchmarkFolder, entry); err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+
+	for _, report := range entry.Reports {
+		if err := index.RecordReport(cfg.BenchmarkFolder, filepath.Join(cfg.BenchmarkFolder, filepath.FromSlash(report.Path)), report); err != nil {
+			fmt.Printf(colorYellow+"Warning: could not record %s in the index: %v%s\n", report.Path, err, colorReset)
+		}
+	}
+	refreshIndex()
+
+	fmt.Printf(colorGreen+"Restored %s and %d report(s)%s\n", entry.Name, len(entry.Reports), colorReset)
+}
+
+func listTrash(entries []trash.Entry) {
+	if len(entries) == 0 {
+		fmt.Println("The trash is empty.")
+		return
+	}
+
+	table := terminal.NewTable(
+		terminal.Column{Header: "Benchmark"},
+		terminal.Column{Header: "Removed"},
+		terminal.Column{Header: "Reports", AlignRight: true},
+	)
+	for _, entry := range entries {
+		table.AddRow(
+			terminal.Colored(entry.Name, terminal.ColorBlue),
+			terminal.Text(entry.DeletedAt.Format("2006-01-02 15:04:05")),
+			terminal.Text(strconv.Itoa(len(entry.Reports))),
+		)
+	}
+	if err := table.Render(os.Stdout, terminal.Width(), terminal.ColorEnabled()); err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+}
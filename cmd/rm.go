@@ -1,82 +1,160 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"masbench/internals/config"
+	"masbench/internals/index"
+	"masbench/internals/trash"
 
 	"github.com/spf13/cobra"
 )
 
+var rmDryRun bool
+var rmYes bool
+
 func init() {
 	rootCmd.AddCommand(rmCmd)
+	rmCmd.Flags().BoolVar(&rmDryRun, "dry-run", false, "List what would be removed without removing anything")
+	rmCmd.Flags().BoolVarP(&rmYes, "yes", "y", false, "Do not ask for confirmation")
 }
 
 var rmCmd = &cobra.Command{
-	Use:   "rm [benchmark_name]",
-	Short: "Remove the specified benchmark",
-	Long:  `Remove the benchmark by specifying its name, this will delete all the data, log and comparisions related to that benchmark`,
-	Args:  cobra.ExactArgs(1),
+	Use:   "rm <benchmark> [benchmark] ...",
+	Short: "Remove the specified benchmarks",
+	Long: `Remove benchmarks with their data, logs and the reports covering them,
+such as the comparisons they are part of.
+
+The benchmarks can be given as selectors, e.g. 'old-*' or tag:scratch, see
+masbench compare --help. Benchmarks without results, such as interrupted
+runs, can be removed by name or glob.
+
+The removed files are moved to the ` + trash.Folder + ` folder of the benchmark
+folder, restore them with masbench restore <benchmark>. Delete the
+` + trash.Folder + ` folder to free the space for good.
+
+masbench lists what will be removed and asks for confirmation, use --yes to
+skip it, e.g. in scripts, and --dry-run to only list what would be removed.
+
+Examples:
+  masbench rm old-experiment
+  masbench rm 'scratch-*' --dry-run
+  masbench rm tag:scratch --yes`,
+	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		benchmarkName := args[0]
-		rm(benchmarkName)
+		migrateReportFolders()
+		rm(selectBenchmarkFolders(args))
 	},
 }
 
-func rm(benchmarkName string) {
+// removal is a benchmark to remove with the reports covering it
+type removal struct {
+	name    string
+	reports []index.Report
+}
+
+func rm(benchmarkNames []string) {
 	cfg := config.GetConfig()
-	benchFolder := cfg.BenchmarkFolder
 
-	dirs, err := os.ReadDir(benchFolder)
-	if err != nil {
-		fmt.Printf("failed to read directory %s: %s\n", benchFolder, err.Error())
-		return
+	// A report covering several removed benchmarks is removed with the first
+	removals := make([]removal, len(benchmarkNames))
+	removed := make(map[string]bool)
+	reportCount := 0
+	for i, name := range benchmarkNames {
+		removals[i].name = name
+		dependents, err := index.Dependents(cfg.BenchmarkFolder, name)
+		if err != nil {
+			fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+			os.Exit(1)
+		}
+		for _, report := range dependents {
+			if !removed[report.Path] {
+				removed[report.Path] = true
+				removals[i].reports = append(removals[i].reports, report)
+				reportCount++
+			}
+		}
 	}
 
-	benchNotFound := true
-	for _, entry := range dirs {
-		if entry.Name() == benchmarkName && entry.IsDir() {
-			benchNotFound = false
-			break
+	if rmDryRun {
+		fmt.Println("Would remove:")
+	} else {
+		fmt.Println("Will remove:")
+	}
+	for _, r := range removals {
+		fmt.Printf("  %s\n", r.name)
+		for _, report := range r.reports {
+			fmt.Printf("    %s\n", report.Path)
 		}
 	}
-
-	if benchNotFound {
-		fmt.Printf("No benchmark called %s was found!!!", benchmarkName)
+	if rmDryRun {
 		return
 	}
 
-	err = os.RemoveAll(filepath.Join(benchFolder, benchmarkName))
-	if err != nil {
-		fmt.Printf("Impossible to delete %s folder, %s", benchFolder, err.Error())
+	question := fmt.Sprintf("Move %d benchmark(s) and %d report(s) to the trash?", len(removals), reportCount)
+	if !rmYes && !confirm(question) {
+		fmt.Println("Aborted, nothing was removed.")
 		return
 	}
 
-	compFolder := filepath.Join(benchFolder, "comparisons")
+	defer refreshIndex()
+	for _, r := range removals {
+		if _, err := trash.Move(cfg.BenchmarkFolder, r.name, r.reports); err != nil {
+			fmt.Printf(colorRed+"Error removing %s: %v%s\n", r.name, err, colorReset)
+			return
+		}
+		fmt.Printf(colorGreen+"Removed %s, restore it with masbench restore %s%s\n", r.name, r.name, colorReset)
+	}
+}
 
-	if _, err := os.Stat(compFolder); os.IsNotExist(err) {
-		return
+// confirm asks a yes or no question on the terminal, anything but yes is no
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Println()
+		return false
 	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// removeBenchmark moves a benchmark and the reports covering it to the
+// trash, without asking for confirmation
+func removeBenchmark(benchmarkName string) error {
+	cfg := config.GetConfig()
 
-	comparisonsDir, err := os.ReadDir(compFolder)
+	if err := checkBenchmarkExists(benchmarkName); err != nil {
+		return err
+	}
+	dependents, err := index.Dependents(cfg.BenchmarkFolder, benchmarkName)
 	if err != nil {
-		fmt.Printf("failed to read directory %s: %s\n", compFolder, err.Error())
-		return
+		return err
 	}
+	defer refreshIndex()
 
-	for _, entry := range comparisonsDir {
-		dirName := entry.Name()
-		benchmarks := strings.Split(dirName, "vs")
+	_, err = trash.Move(cfg.BenchmarkFolder, benchmarkName, dependents)
+	return err
+}
 
-		if len(benchmarks) < 2 {
-			continue
-		}
+// checkBenchmarkExists returns an error if there is no benchmark folder
+// called benchmarkName
+func checkBenchmarkExists(benchmarkName string) error {
+	cfg := config.GetConfig()
+	benchFolder := cfg.BenchmarkFolder
+
+	dirs, err := os.ReadDir(benchFolder)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %s", benchFolder, err.Error())
+	}
 
-		if benchmarks[0] == benchmarkName || benchmarks[1] == benchmarkName {
-			os.RemoveAll(filepath.Join(compFolder, dirName))
+	for _, entry := range dirs {
+		if entry.Name() == benchmarkName && entry.IsDir() {
+			return nil
 		}
 	}
+	return fmt.Errorf("No benchmark called %s was found!!!", benchmarkName)
 }
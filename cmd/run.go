@@ -7,20 +7,29 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"masbench/internals/config"
+	"masbench/internals/junit"
+	"masbench/internals/models"
 	"masbench/internals/parsers"
+	"masbench/internals/utils"
 
 	"github.com/spf13/cobra"
 )
 
 var message string
 var algorithm string
+var repeat int
+var runTags []string
 
 func init() {
 	rootCmd.AddCommand(runCmd)
 	runCmd.Flags().StringVarP(&message, "message", "m", "", "Add a note to the run")
 	runCmd.Flags().StringVarP(&algorithm, "algorithm", "a", "", "Algorithm to use for this run")
+	runCmd.Flags().IntVarP(&repeat, "repeat", "r", 1, "Number of times every level is run")
+	runCmd.Flags().StringSliceVarP(&runTags, "tag", "t", nil, "Tag the run, can be repeated")
+	addJUnitFlag(runCmd)
 }
 
 var runCmd = &cobra.Command{
@@ -59,6 +68,25 @@ OPTIONS
            Useful for documenting the purpose of a run, configuration
            changes, or any other relevant information about the benchmark.
 
+       -r <count>, --repeat=<count>
+           Run the whole set of levels <count> times. Every run adds one row
+           per level to the results CSV, which lets compare tell real
+           differences apart from noise. At least 4 runs per benchmark are
+           needed for a difference to be significant at the default 0.05
+           significance level.
+
+       -t <tag>, --tag=<tag>
+           Tag the run, e.g. nightly or competition. Repeat the flag or
+           separate tags with commas to add several. Tagged runs can be
+           selected with tag:<tag> and listed with masbench list --tag.
+           Tags can be changed later with masbench tag.
+
+       --junit <file>
+           Also write the results as JUnit XML to <file>, with one testcase
+           per level. Unsolved levels are reported as failures and every
+           testcase carries the time, actions, explored and generated
+           values as properties.
+
 EXAMPLES
        Run a benchmark named "test-run":
            masbench run test-run
@@ -67,18 +95,33 @@ EXAMPLES
            masbench run astar-test -a astar
 
        Run with a descriptive message:
-           masbench run baseline -m "Baseline performance test"`,
+           masbench run baseline -m "Baseline performance test"
+
+       Run with tags:
+           masbench run nightly-2026-10-19 -t nightly -t astar
+
+       Run every level five times:
+           masbench run astar-repeated -a astar -r 5
+
+       Run in CI and publish the results as a test report:
+           masbench run ci-run --junit results.xml`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		benchmarkName := args[0]
+		validateTagsOrExit(runTags)
 		fmt.Printf("Running benchmark: %s\n", benchmarkName)
-		runBenchmark(benchmarkName, message, algorithm)
+		runBenchmark(benchmarkName, message, algorithm, repeat, runTags)
 	},
 }
 
-func runBenchmark(name, message, algorithm string) {
+func runBenchmark(name, message, algorithm string, repeat int, tags []string) {
 	cfg := config.GetConfig()
 
+	if repeat < 1 {
+		fmt.Printf("\033[31mError: --repeat must be at least 1, got %d\033[0m\n", repeat)
+		return
+	}
+
 	// Create benchmark folder if it does not exist
 	if _, err := os.Stat(cfg.BenchmarkFolder); os.IsNotExist(err) {
 		if err := os.MkdirAll(cfg.BenchmarkFolder, os.ModePerm); err != nil {
@@ -115,28 +158,40 @@ func runBenchmark(name, message, algorithm string) {
 
 	if algorithm != "" {
 		if strings.Count(cfg.AlgorithmFlagFormat, "%s") != 1 {
-			fmt.Println("\033[31mError in your configuration: The parameter AlgorithmFlagFormat in your masbench_config.yml must contain only one %s'\033[0m")
+			fmt.Printf("\033[31mError in your configuration: The parameter AlgorithmFlagFormat in your masbench_config.yml must contain only one %%s\033[0m\n")
 			return
 		}
 		cfg.ClientCommand += " " + fmt.Sprintf(cfg.AlgorithmFlagFormat, algorithm)
 	}
 
-	cmd := exec.Command("java", "-jar", cfg.ServerPath,
-		"-l", cfg.LevelsDir,
-		"-o", logServerPath,
-		"-c", cfg.ClientCommand,
-		"-t", fmt.Sprintf("%d", cfg.Timeout),
-	)
-
 	multiWriter := io.MultiWriter(os.Stdout, logFile)
-	cmd.Stdout = multiWriter
-	cmd.Stderr = multiWriter
+	startedAt := time.Now()
 
-	err = cmd.Run()
-	if err != nil {
-		fmt.Printf("\033[31mError running benchmark: %v\033[0m\n", err)
-		os.RemoveAll(benchmarkPath)
-		return
+	for run := 1; run <= repeat; run++ {
+		serverLog := logServerPath
+		if run > 1 {
+			serverLog = filepath.Join(logDir, fmt.Sprintf("%s_server_%d.zip", name, run))
+		}
+		if repeat > 1 {
+			fmt.Printf("Run %d of %d\n", run, repeat)
+		}
+
+		cmd := exec.Command("java", "-jar", cfg.ServerPath,
+			"-l", cfg.LevelsDir,
+			"-o", serverLog,
+			"-c", cfg.ClientCommand,
+			"-t", fmt.Sprintf("%d", cfg.Timeout),
+		)
+
+		cmd.Stdout = multiWriter
+		cmd.Stderr = multiWriter
+
+		err = cmd.Run()
+		if err != nil {
+			fmt.Printf("\033[31mError running benchmark: %v\033[0m\n", err)
+			os.RemoveAll(benchmarkPath)
+			return
+		}
 	}
 
 	fmt.Println("\033[32mBenchmark run completed successfully.\033[0m")
@@ -155,5 +210,30 @@ func runBenchmark(name, message, algorithm string) {
 		fmt.Printf("\033[31mError! Couldn't write in %s \n %v\033[0m\n", descriptionFilePath, err)
 	}
 
+	meta := models.RunMetadata{
+		Name:       name,
+		Algorithm:  algorithm,
+		Repeat:     repeat,
+		Timeout:    cfg.Timeout,
+		StartedAt:  startedAt,
+		FinishedAt: time.Now(),
+		Tags:       tags,
+	}
+	if err := utils.WriteMetadata(cfg.BenchmarkFolder, meta); err != nil {
+		fmt.Printf("\033[31mError! %v\033[0m\n", err)
+	}
+
 	fmt.Printf("\033[32mResults successfully written to %s\033[0m\n", csvOutputPath)
+	refreshIndex()
+
+	if junitPath != "" {
+		df, err := utils.LoadCSV(csvOutputPath)
+		if err != nil {
+			fmt.Printf("\033[31mError reading results CSV: %v\033[0m\n", err)
+			return
+		}
+		if err := writeJUnit(name, junit.FromResults(name, df)); err != nil {
+			fmt.Printf("\033[31mError writing JUnit report: %v\033[0m\n", err)
+		}
+	}
 }
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"masbench/internals/config"
+	"masbench/internals/server"
+)
+
+var (
+	servePort int
+	serveHost string
+)
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().IntVar(&servePort, "port", 8080, "Port to serve the dashboard on")
+	serveCmd.Flags().StringVar(&serveHost, "host", "localhost", "Host to serve the dashboard on, use 0.0.0.0 to share it on the network")
+	addToleranceFlags(serveCmd)
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Browse, compare and summarize the benchmarks in a local web dashboard",
+	Long: `Serve a dashboard over the benchmark folder. It lists the benchmarks with
+their descriptions and headline numbers, and compares or summarizes any
+selection of them on the fly, without writing reports to the folder.
+
+Select two benchmarks to compare them, or pick a baseline to compare every
+selected benchmark against it. The raw client log of every level can be
+viewed, and benchmarks can be renamed or deleted like with masbench rm.
+
+Examples:
+  masbench serve
+  masbench serve --port 9000
+  masbench serve --host 0.0.0.0
+
+The dashboard has no authentication, only serve it on a network you trust.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.GetConfig()
+		// Built once, requests only read the configuration
+		opts := comparisonOptions(cfg)
+
+		dashboard, err := server.New(cfg.BenchmarkFolder, opts, server.Actions{
+			Remove: removeBenchmark,
+			Rename: func(oldName, newName string) error {
+				return renameBenchmark(oldName, newName, opts)
+			},
+		})
+		if err != nil {
+			fmt.Printf(colorRed+"Error creating the dashboard: %v%s\n", err, colorReset)
+			os.Exit(1)
+		}
+
+		addr := net.JoinHostPort(serveHost, strconv.Itoa(servePort))
+		fmt.Printf(colorGreen+"Serving the dashboard on http://%s, press Ctrl+C to stop%s\n", addr, colorReset)
+		if err := dashboard.ListenAndServe(addr); err != nil {
+			fmt.Printf(colorRed+"Error serving the dashboard: %v%s\n", err, colorReset)
+			os.Exit(1)
+		}
+	},
+}
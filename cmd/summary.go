@@ -1,17 +1,37 @@
 package cmd
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 	"masbench/internals/config"
+	"masbench/internals/index"
+	"masbench/internals/models"
 	"masbench/internals/summarizer"
+	"masbench/internals/terminal"
 )
 
+var paretoMetrics []string
+var summaryName string
+
+// maxSummaryNameLength is the longest default summary name, longer sets of
+// benchmarks are shortened and told apart by a hash
+const maxSummaryNameLength = 60
+
 func init() {
 	rootCmd.AddCommand(summaryCmd)
+	addToleranceFlags(summaryCmd)
+	addFormatFlag(summaryCmd)
+	summaryCmd.Flags().StringVar(&summaryName, "name", "", "Name of the summary, used for its file name (default derived from the benchmarks)")
+	summaryCmd.Flags().StringSliceVar(&paretoMetrics, "pareto", nil, "Metrics of the Pareto fronts, e.g. time,actions,explored (default from config)")
 }
 
 var summaryCmd = &cobra.Command{
@@ -22,25 +42,63 @@ var summaryCmd = &cobra.Command{
 This command creates a comprehensive summary showing:
 - Which benchmark(s) solved the most levels
 - Which benchmark completed in the least time (with timeout for unsolved levels)
-- Per-level comparison of fastest time and fewest actions
+- Per-level Pareto fronts: the benchmarks no other benchmark beats on every metric
 
 Examples:
   masbench summary astar-v1
   masbench summary astar-v1 bfs-v1 dijkstra-v1
+  masbench summary 'astar-*' tag:baseline
+
+` + selectorHelp + `
+
+A benchmark is Pareto-optimal on a level when no other benchmark is at least
+as good on every metric and better on one. The fronts use Time and Actions
+unless ParetoMetrics is set in masbench_config.yml or --pareto is given, e.g.
+--pareto time,actions,explored,memory.
+
+Differences within the tolerance of a metric count as a tie. Override the
+tolerances from masbench_config.yml with --rel-tol and --abs-tol.
+
+Use --format table to print the summary in the terminal instead of writing
+an HTML file, --format markdown to write a GitHub-flavored Markdown file or
+--format json to export the full summary. Use --output to choose where the
+report is written, --output - prints it to stdout.
+
+The summary is named after its benchmarks, e.g. astar-v1+bfs-v1_summary.html,
+so summaries of different sets of benchmarks do not overwrite each other.
+Use --name to choose the name:
+  masbench summary astar-v1 bfs-v1 dijkstra-v1 --name heuristics
 
-The generated HTML report provides an easy-to-understand overview of benchmark performance.`,
+The generated HTML report provides an easy-to-understand overview of benchmark performance.
+Its styles are embedded in the file, so it also works offline.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) < 1 {
 			fmt.Println(colorRed + "Error: You must provide at least one benchmark name." + colorReset)
 			os.Exit(1)
 		}
 
-		generateSummary(args)
+		validateFormatOrExit()
+		generateSummary(selectBenchmarks(args))
 	},
 }
 
 func generateSummary(benchmarkNames []string) {
 	cfg := config.GetConfig()
+	if err := applyToleranceFlags(cfg); err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+	if len(paretoMetrics) > 0 {
+		cfg.ParetoMetrics = make([]string, len(paretoMetrics))
+		for i, metric := range paretoMetrics {
+			column, err := models.MetricColumn(metric)
+			if err != nil {
+				fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+				os.Exit(1)
+			}
+			cfg.ParetoMetrics[i] = column
+		}
+	}
 
 	benchmarkPaths := make(map[string]string)
 	for _, name := range benchmarkNames {
@@ -52,24 +110,75 @@ func generateSummary(benchmarkNames []string) {
 		benchmarkPaths[name] = path
 	}
 
-	outputDir := filepath.Join(cfg.BenchmarkFolder, "summaries")
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		fmt.Printf(colorRed+"Error creating output directory: %v%s\n", err, colorReset)
+	report, err := summarizer.PrepareSummaryData(benchmarkPaths)
+	if err != nil {
+		fmt.Printf(colorRed+"Error preparing summary: %v%s\n", err, colorReset)
 		os.Exit(1)
 	}
 
-	summaryName := benchmarkNames[0]
-	if len(benchmarkNames) > 1 {
-		summaryName = "multi_benchmark"
+	name := summaryName
+	if name == "" {
+		name = defaultSummaryName(benchmarkNames)
+	} else if name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		fmt.Printf(colorRed+"Error: invalid summary name %q, it must be a plain file name%s\n", name, colorReset)
+		os.Exit(1)
 	}
-	reportPath := filepath.Join(outputDir, fmt.Sprintf("%s_summary.html", summaryName))
 
-	if err := summarizer.GenerateHTMLSummary(benchmarkPaths, reportPath); err != nil {
-		fmt.Printf(colorRed+"Error creating HTML summary: %v%s\n", err, colorReset)
-		os.Exit(1)
+	outputDir := filepath.Join(cfg.BenchmarkFolder, "summaries")
+	reportPath := writeReport(outputDir, name+"_summary", func(w io.Writer) error {
+		return writeSummary(w, outputFormat, report)
+	})
+	printReportPath("Summary", reportPath)
+	warnMissingScripts(reportPath, "chart.js")
+	recordReport(reportPath, index.KindSummary, report.Benchmarks, summaryHeadline(report))
+}
+
+// writeSummary writes a summary in the given format
+func writeSummary(w io.Writer, format string, report summarizer.SummaryReport) error {
+	switch format {
+	case formatTable:
+		return summarizer.WriteTable(w, report, terminal.Width(), reportColor())
+	case formatMarkdown:
+		return summarizer.WriteMarkdown(w, report)
+	case formatJSON:
+		return summarizer.WriteJSON(w, report)
+	default:
+		return summarizer.WriteHTML(w, report)
+	}
+}
+
+// defaultSummaryName derives the summary name from the set of benchmarks, in
+// any order: a+b+c. Long sets keep the first benchmarks and a hash of all,
+// a single long name is cut and followed by its hash
+func defaultSummaryName(benchmarkNames []string) string {
+	names := slices.Clone(benchmarkNames)
+	sort.Strings(names)
+	names = slices.Compact(names)
+
+	name := strings.Join(names, "+")
+	if len(name) <= maxSummaryNameLength {
+		return name
 	}
 
-	fmt.Printf(colorGreen+"Summary completed successfully!%s\n", colorReset)
-	fmt.Printf(colorGreen+"HTML Report: %s%s\n", reportPath, colorReset)
-	fmt.Printf(colorYellow+"Open the HTML file in your browser to view the interactive report.%s\n", colorReset)
+	hash := sha256.Sum256([]byte(name))
+	if len(names) == 1 {
+		cut := maxSummaryNameLength
+		for cut > 0 && !utf8.RuneStart(name[cut]) {
+			cut--
+		}
+		return fmt.Sprintf("%s_%x", name[:cut], hash[:4])
+	}
+	return fmt.Sprintf("%s+%s+%d_more_%x", names[0], names[1], len(names)-2, hash[:4])
+}
+
+// summaryHeadline names the benchmarks solving the most levels for the index
+func summaryHeadline(report summarizer.SummaryReport) string {
+	var best []string
+	for _, stat := range report.OverallStats.MostLevelsSolved {
+		best = append(best, stat.Name)
+	}
+	if len(best) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Most levels solved: %s (%s)", strings.Join(best, ", "), report.OverallStats.MostLevelsSolved[0].Value)
 }
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"masbench/internals/config"
+	"masbench/internals/utils"
+)
+
+// tagPattern is the syntax of a tag, tags are used in selectors such as
+// tag:nightly so they cannot contain spaces or glob characters
+var tagPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+func init() {
+	rootCmd.AddCommand(tagCmd)
+	tagCmd.AddCommand(tagAddCmd)
+	tagCmd.AddCommand(tagRmCmd)
+}
+
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Add or remove the tags of benchmarks",
+	Long: `Tags group benchmarks, e.g. the nightly runs or the runs submitted to the
+competition. Tag benchmarks when running them with masbench run --tag, or
+afterwards with masbench tag add.
+
+Tagged benchmarks can be selected with tag:<tag> in every command taking
+benchmark names, and listed with masbench list --tag <tag>.
+
+Tags may contain letters, digits, '.', '_' and '-'.
+
+Examples:
+  masbench tag add astar-v3 competition
+  masbench tag add 'astar-*' astar heuristics
+  masbench tag rm astar-v3 competition`,
+}
+
+var tagAddCmd = &cobra.Command{
+	Use:   "add <benchmark> <tag> [tag] ...",
+	Short: "Tag one or more benchmarks",
+	Long: `Add tags to the benchmarks selected by <benchmark>, which can be any
+selector, e.g. 'astar-*' to tag every A* benchmark.`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		validateTagsOrExit(args[1:])
+		for _, name := range selectBenchmarks(args[:1]) {
+			updateTags(name, func(tags []string) []string {
+				for _, tag := range args[1:] {
+					if !slices.Contains(tags, tag) {
+						tags = append(tags, tag)
+					}
+				}
+				return tags
+			})
+		}
+		refreshIndex()
+	},
+}
+
+var tagRmCmd = &cobra.Command{
+	Use:   "rm <benchmark> <tag> [tag] ...",
+	Short: "Remove tags from one or more benchmarks",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, name := range selectBenchmarks(args[:1]) {
+			updateTags(name, func(tags []string) []string {
+				return slices.DeleteFunc(tags, func(tag string) bool { return slices.Contains(args[1:], tag) })
+			})
+		}
+		refreshIndex()
+	},
+}
+
+// validateTagsOrExit exits if one of the tags is not a valid tag
+func validateTagsOrExit(tags []string) {
+	for _, tag := range tags {
+		if !tagPattern.MatchString(tag) {
+			fmt.Printf(colorRed+"Error: invalid tag %q, tags may contain letters, digits, '.', '_' and '-'%s\n", tag, colorReset)
+			os.Exit(1)
+		}
+	}
+}
+
+// updateTags replaces the tags of a benchmark with the result of update and
+// prints them, exiting if the metadata cannot be updated
+func updateTags(name string, update func([]string) []string) {
+	cfg := config.GetConfig()
+	meta, err := utils.LoadMetadata(cfg.BenchmarkFolder, name)
+	if err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+
+	meta.Tags = update(meta.Tags)
+	if err := utils.WriteMetadata(cfg.BenchmarkFolder, meta); err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+	fmt.Printf(colorGreen+"%s: %s%s\n", name, formatTags(meta.Tags), colorReset)
+}
+
+// formatTags lists tags separated by commas, or - without tags
+func formatTags(tags []string) string {
+	if len(tags) == 0 {
+		return "-"
+	}
+	return strings.Join(tags, ", ")
+}
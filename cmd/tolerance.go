@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"masbench/internals/models"
+
+	"github.com/spf13/cobra"
+)
+
+var relTolerances map[string]string
+var absTolerances map[string]string
+var significanceLevel float64
+
+// addToleranceFlags registers the flags overriding the Tolerances and
+// SignificanceLevel settings of masbench_config.yml
+func addToleranceFlags(cmd *cobra.Command) {
+	cmd.Flags().StringToStringVar(&relTolerances, "rel-tol", nil, "Relative tolerance per metric as a fraction, e.g. Time=0.05,Actions=0")
+	cmd.Flags().StringToStringVar(&absTolerances, "abs-tol", nil, "Absolute tolerance per metric in the metric unit, e.g. Time=0.1")
+	cmd.Flags().Float64Var(&significanceLevel, "alpha", 0, "Significance level used when benchmarks have repeated runs (default from config)")
+}
+
+// applyToleranceFlags merges the values passed on the command line into cfg
+func applyToleranceFlags(cfg *models.Config) error {
+	if cfg.Tolerances == nil {
+		cfg.Tolerances = make(map[string]models.Tolerance)
+	}
+
+	for metric, value := range relTolerances {
+		name, tol, err := parseTolerance(metric, value)
+		if err != nil {
+			return err
+		}
+		t := cfg.Tolerances[name]
+		t.Relative = tol
+		cfg.Tolerances[name] = t
+	}
+
+	for metric, value := range absTolerances {
+		name, tol, err := parseTolerance(metric, value)
+		if err != nil {
+			return err
+		}
+		t := cfg.Tolerances[name]
+		t.Absolute = tol
+		cfg.Tolerances[name] = t
+	}
+
+	if significanceLevel != 0 {
+		if significanceLevel <= 0 || significanceLevel >= 1 {
+			return fmt.Errorf("--alpha must be between 0 and 1, got %g", significanceLevel)
+		}
+		cfg.SignificanceLevel = significanceLevel
+	}
+
+	return nil
+}
+
+func parseTolerance(metric, value string) (string, float64, error) {
+	name, err := models.MetricColumn(metric)
+	if err != nil {
+		return "", 0, err
+	}
+
+	tol, err := strconv.ParseFloat(value, 64)
+	if err != nil || tol < 0 {
+		return "", 0, fmt.Errorf("invalid tolerance %q for %s", value, name)
+	}
+
+	return name, tol, nil
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"masbench/internals/config"
+	"masbench/internals/index"
+	"masbench/internals/terminal"
+	"masbench/internals/trend"
+	"masbench/internals/utils"
+)
+
+func init() {
+	rootCmd.AddCommand(trendCmd)
+	addFormatFlag(trendCmd)
+}
+
+var trendCmd = &cobra.Command{
+	Use:   "trend <selector> [selector] ...",
+	Short: "Track the progress of a series of benchmarks over time",
+	Long: `Follow a series of benchmarks, such as v1, v2, v3, over time. The
+benchmarks are selected with globs on their names, tags or dates, and
+ordered by the time they were run.
+
+The report shows, for every run, the solved levels, the total and geometric
+mean time and actions, and for every level a sparkline of its time across
+the runs, the run where it was first solved and the runs where it was no
+longer solved.
+
+` + selectorHelp + `
+
+Examples:
+  masbench trend 'astar-v*'
+  masbench trend 'v*' --format table
+  masbench trend tag:competition
+  masbench trend since:2026-09-01..2026-09-30
+
+Benchmarks run with older versions of masbench have no run metadata, they
+are ordered by the modification time of their results.
+
+Use --format table to print the trend in the terminal, --format markdown or
+--format json to export it, and --output to choose where it is written.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			fmt.Println(colorRed + "Error: You must provide at least one benchmark selector." + colorReset)
+			os.Exit(1)
+		}
+
+		validateFormatOrExit()
+		generateTrend(args)
+	},
+}
+
+func generateTrend(patterns []string) {
+	cfg := config.GetConfig()
+
+	names := selectBenchmarks(patterns)
+
+	inputs, err := trendInputs(names)
+	if err != nil {
+		fmt.Printf(colorRed+"Error: %v%s\n", err, colorReset)
+		os.Exit(1)
+	}
+	report := trend.Compute(inputs, cfg.Timeout)
+
+	outputDir := filepath.Join(cfg.BenchmarkFolder, "trends")
+	reportPath := writeReport(outputDir, trendName(patterns)+"_trend", func(w io.Writer) error {
+		return writeTrend(w, outputFormat, report)
+	})
+	printReportPath("Trend", reportPath)
+	warnMissingScripts(reportPath, "chart.js")
+	recordReport(reportPath, index.KindTrend, names, trendHeadline(report))
+}
+
+// trendInputs loads the results, metadata and description of the benchmarks
+func trendInputs(names []string) ([]trend.Input, error) {
+	cfg := config.GetConfig()
+	inputs := make([]trend.Input, len(names))
+	for i, name := range names {
+		meta, err := utils.LoadMetadata(cfg.BenchmarkFolder, name)
+		if err != nil {
+			return nil, err
+		}
+		df, err := utils.LoadCSV(filepath.Join(cfg.BenchmarkFolder, name, fmt.Sprintf("%s_results.csv", name)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the results of %s: %w", name, err)
+		}
+		inputs[i] = trend.Input{
+			Name:        name,
+			Description: index.Description(cfg.BenchmarkFolder, name),
+			StartedAt:   meta.StartedAt,
+			Results:     df,
+		}
+	}
+	return inputs, nil
+}
+
+// writeTrend writes a trend in the given format
+func writeTrend(w io.Writer, format string, report trend.Report) error {
+	switch format {
+	case formatTable:
+		return trend.WriteTable(w, report, terminal.Width(), reportColor())
+	case formatMarkdown:
+		return trend.WriteMarkdown(w, report)
+	case formatJSON:
+		return trend.WriteJSON(w, report)
+	default:
+		return trend.WriteHTML(w, report)
+	}
+}
+
+var unsafeNameChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// trendName turns the patterns into a file name, e.g. astar-v* becomes astar-v_
+func trendName(patterns []string) string {
+	name := strings.Trim(unsafeNameChars.ReplaceAllString(strings.Join(patterns, "_"), "_"), "_.")
+	if name == "" {
+		return "all"
+	}
+	return name
+}
+
+// trendHeadline describes the progress over the series for the index
+func trendHeadline(report trend.Report) string {
+	first, last := report.Runs[0], report.Runs[len(report.Runs)-1]
+	return fmt.Sprintf("%d runs, solved %d → %d of %d levels", len(report.Runs), first.Solved, last.Solved, last.TotalLevels)
+}
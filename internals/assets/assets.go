@@ -0,0 +1,65 @@
+// Package assets embeds the stylesheet and scripts of the HTML reports so that
+// reports work offline, without loading anything from a CDN
+package assets
+
+//go:generate go run gen_tailwind.go
+//go:generate go run fetch_vendor.go
+
+import (
+	"embed"
+	"html/template"
+	"strings"
+)
+
+//go:embed tailwind.css
+var tailwindCSS string
+
+//go:embed vendor
+var vendor embed.FS
+
+// Script is a third-party script used by the reports
+type Script struct {
+	Name string // name used by the templates
+	File string // file in vendor/
+	URL  string // pinned CDN location, used when the file is not embedded
+}
+
+// Scripts are the third-party scripts used by the reports
+var Scripts = []Script{
+	{Name: "chart.js", File: "chart.umd.min.js", URL: "https://cdn.jsdelivr.net/npm/chart.js@4.4.0/dist/chart.umd.min.js"},
+	{Name: "html2canvas", File: "html2canvas.min.js", URL: "https://cdn.jsdelivr.net/npm/html2canvas@1.4.1/dist/html2canvas.min.js"},
+}
+
+// Styles returns the report stylesheet as an inline style element
+func Styles() template.HTML {
+	return template.HTML("<style>\n" + tailwindCSS + "</style>")
+}
+
+// ScriptTag returns the named script as an inline script element, or as a
+// CDN script element when it is not embedded
+func ScriptTag(name string) template.HTML {
+	for _, script := range Scripts {
+		if script.Name != name {
+			continue
+		}
+		data, err := vendor.ReadFile("vendor/" + script.File)
+		if err != nil {
+			return template.HTML(`<script src="` + script.URL + `"></script>`)
+		}
+		// A literal "</script" would close the element early
+		source := strings.ReplaceAll(string(data), "</script", `<\/script`)
+		return template.HTML("<script>\n" + source + "\n</script>")
+	}
+	return ""
+}
+
+// Missing returns the scripts that are not embedded in this build
+func Missing() []Script {
+	var missing []Script
+	for _, script := range Scripts {
+		if _, err := vendor.ReadFile("vendor/" + script.File); err != nil {
+			missing = append(missing, script)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,45 @@
+//go:build ignore
+
+// fetch_vendor downloads the pinned third-party scripts of the reports into
+// vendor/, from where they are embedded into the binary
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"masbench/internals/assets"
+)
+
+func main() {
+	for _, script := range assets.Scripts {
+		if err := fetch(script); err != nil {
+			fmt.Fprintln(os.Stderr, "fetch_vendor:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("fetched %s\n", script.File)
+	}
+}
+
+func fetch(script assets.Script) error {
+	resp, err := http.Get(script.URL)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", script.Name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: %s", script.Name, resp.Status)
+	}
+
+	file, err := os.Create(filepath.Join("vendor", script.File))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, resp.Body)
+	return err
+}
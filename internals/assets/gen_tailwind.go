@@ -0,0 +1,432 @@
+//go:build ignore
+
+// gen_tailwind generates tailwind.css, a static stylesheet with the Tailwind
+// CSS v3 utility classes used by the report templates, so that reports are
+// styled without loading the Tailwind CDN. Run it with go generate after
+// adding classes to a template; it fails on classes it does not know.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var palette = map[string][]string{
+	"gray":   {"#f9fafb", "#f3f4f6", "#e5e7eb", "#d1d5db", "#9ca3af", "#6b7280", "#4b5563", "#374151", "#1f2937", "#111827"},
+	"red":    {"#fef2f2", "#fee2e2", "#fecaca", "#fca5a5", "#f87171", "#ef4444", "#dc2626", "#b91c1c", "#991b1b", "#7f1d1d"},
+	"orange": {"#fff7ed", "#ffedd5", "#fed7aa", "#fdba74", "#fb923c", "#f97316", "#ea580c", "#c2410c", "#9a3412", "#7c2d12"},
+	"yellow": {"#fefce8", "#fef9c3", "#fef08a", "#fde047", "#facc15", "#eab308", "#ca8a04", "#a16207", "#854d0e", "#713f12"},
+	"green":  {"#f0fdf4", "#dcfce7", "#bbf7d0", "#86efac", "#4ade80", "#22c55e", "#16a34a", "#15803d", "#166534", "#14532d"},
+	"teal":   {"#f0fdfa", "#ccfbf1", "#99f6e4", "#5eead4", "#2dd4bf", "#14b8a6", "#0d9488", "#0f766e", "#115e59", "#134e4a"},
+	"blue":   {"#eff6ff", "#dbeafe", "#bfdbfe", "#93c5fd", "#60a5fa", "#3b82f6", "#2563eb", "#1d4ed8", "#1e40af", "#1e3a8a"},
+	"indigo": {"#eef2ff", "#e0e7ff", "#c7d2fe", "#a5b4fc", "#818cf8", "#6366f1", "#4f46e5", "#4338ca", "#3730a3", "#312e81"},
+	"purple": {"#faf5ff", "#f3e8ff", "#e9d5ff", "#d8b4fe", "#c084fc", "#a855f7", "#9333ea", "#7e22ce", "#6b21a8", "#581c87"},
+	"pink":   {"#fdf2f8", "#fce7f3", "#fbcfe8", "#f9a8d4", "#f472b6", "#ec4899", "#db2777", "#be185d", "#9d174d", "#831843"},
+}
+
+var shades = []string{"50", "100", "200", "300", "400", "500", "600", "700", "800", "900"}
+
+var fontSizes = map[string]string{
+	"xs":   "font-size: 0.75rem; line-height: 1rem",
+	"sm":   "font-size: 0.875rem; line-height: 1.25rem",
+	"base": "font-size: 1rem; line-height: 1.5rem",
+	"lg":   "font-size: 1.125rem; line-height: 1.75rem",
+	"xl":   "font-size: 1.25rem; line-height: 1.75rem",
+	"2xl":  "font-size: 1.5rem; line-height: 2rem",
+	"3xl":  "font-size: 1.875rem; line-height: 2.25rem",
+	"4xl":  "font-size: 2.25rem; line-height: 2.5rem",
+	"5xl":  "font-size: 3rem; line-height: 1",
+}
+
+var maxWidths = map[string]string{
+	"xs": "20rem", "sm": "24rem", "md": "28rem", "lg": "32rem", "xl": "36rem", "2xl": "42rem",
+	"3xl": "48rem", "4xl": "56rem", "5xl": "64rem", "6xl": "72rem", "7xl": "80rem", "full": "100%",
+}
+
+var breakpoints = map[string]string{"sm": "640px", "md": "768px", "lg": "1024px", "xl": "1280px"}
+
+// static utilities, in the order Tailwind emits them
+var static = []struct{ class, css string }{
+	{"relative", "position: relative"},
+	{"absolute", "position: absolute"},
+	{"sticky", "position: sticky"},
+	{"mx-auto", "margin-left: auto; margin-right: auto"},
+	{"block", "display: block"},
+	{"inline-block", "display: inline-block"},
+	{"inline", "display: inline"},
+	{"flex", "display: flex"},
+	{"inline-flex", "display: inline-flex"},
+	{"table", "display: table"},
+	{"grid", "display: grid"},
+	{"hidden", "display: none"},
+	{"w-full", "width: 100%"},
+	{"h-full", "height: 100%"},
+	{"min-w-full", "min-width: 100%"},
+	{"flex-1", "flex: 1 1 0%"},
+	{"flex-shrink-0", "flex-shrink: 0"},
+	{"shrink-0", "flex-shrink: 0"},
+	{"cursor-pointer", "cursor: pointer"},
+	{"flex-row", "flex-direction: row"},
+	{"flex-col", "flex-direction: column"},
+	{"flex-wrap", "flex-wrap: wrap"},
+	{"items-start", "align-items: flex-start"},
+	{"items-end", "align-items: flex-end"},
+	{"items-center", "align-items: center"},
+	{"items-baseline", "align-items: baseline"},
+	{"justify-start", "justify-content: flex-start"},
+	{"justify-end", "justify-content: flex-end"},
+	{"justify-center", "justify-content: center"},
+	{"justify-between", "justify-content: space-between"},
+	{"overflow-auto", "overflow: auto"},
+	{"overflow-hidden", "overflow: hidden"},
+	{"overflow-x-auto", "overflow-x: auto"},
+	{"overflow-y-auto", "overflow-y: auto"},
+	{"truncate", "overflow: hidden; text-overflow: ellipsis; white-space: nowrap"},
+	{"whitespace-nowrap", "white-space: nowrap"},
+	{"rounded", "border-radius: 0.25rem"},
+	{"rounded-md", "border-radius: 0.375rem"},
+	{"rounded-lg", "border-radius: 0.5rem"},
+	{"rounded-xl", "border-radius: 0.75rem"},
+	{"rounded-full", "border-radius: 9999px"},
+	{"border", "border-width: 1px"},
+	{"border-0", "border-width: 0px"},
+	{"border-2", "border-width: 2px"},
+	{"border-b", "border-bottom-width: 1px"},
+	{"border-t", "border-top-width: 1px"},
+	{"border-l", "border-left-width: 1px"},
+	{"border-r", "border-right-width: 1px"},
+	{"border-b-2", "border-bottom-width: 2px"},
+	{"border-t-2", "border-top-width: 2px"},
+	{"border-l-4", "border-left-width: 4px"},
+	{"text-left", "text-align: left"},
+	{"text-center", "text-align: center"},
+	{"text-right", "text-align: right"},
+	{"font-mono", "font-family: ui-monospace, SFMono-Regular, Menlo, Monaco, Consolas, \"Liberation Mono\", \"Courier New\", monospace"},
+	{"font-normal", "font-weight: 400"},
+	{"font-medium", "font-weight: 500"},
+	{"font-semibold", "font-weight: 600"},
+	{"font-bold", "font-weight: 700"},
+	{"uppercase", "text-transform: uppercase"},
+	{"capitalize", "text-transform: capitalize"},
+	{"italic", "font-style: italic"},
+	{"tracking-wide", "letter-spacing: 0.025em"},
+	{"tracking-wider", "letter-spacing: 0.05em"},
+	{"underline", "text-decoration-line: underline"},
+	{"opacity-50", "opacity: 0.5"},
+	{"opacity-75", "opacity: 0.75"},
+	{"shadow-sm", "box-shadow: 0 1px 2px 0 rgb(0 0 0 / 0.05)"},
+	{"shadow", "box-shadow: 0 1px 3px 0 rgb(0 0 0 / 0.1), 0 1px 2px -1px rgb(0 0 0 / 0.1)"},
+	{"shadow-md", "box-shadow: 0 4px 6px -1px rgb(0 0 0 / 0.1), 0 2px 4px -2px rgb(0 0 0 / 0.1)"},
+	{"shadow-lg", "box-shadow: 0 10px 15px -3px rgb(0 0 0 / 0.1), 0 4px 6px -4px rgb(0 0 0 / 0.1)"},
+	{"outline-none", "outline: 2px solid transparent; outline-offset: 2px"},
+	{"ring-2", "box-shadow: 0 0 0 2px var(--tw-ring-color, rgb(59 130 246 / 0.5))"},
+	{"transition", "transition-property: color, background-color, border-color, text-decoration-color, fill, stroke, opacity, box-shadow, transform, filter; transition-timing-function: cubic-bezier(0.4, 0, 0.2, 1); transition-duration: 150ms"},
+	{"transition-colors", "transition-property: color, background-color, border-color, text-decoration-color, fill, stroke; transition-timing-function: cubic-bezier(0.4, 0, 0.2, 1); transition-duration: 150ms"},
+	{"duration-200", "transition-duration: 200ms"},
+	{"duration-300", "transition-duration: 300ms"},
+}
+
+// Order of the generated utilities, later groups win over earlier ones
+const (
+	orderStatic = iota * 1000
+	orderSizing
+	orderGrid
+	orderSpacing
+	orderSides
+	orderColor
+)
+
+type rule struct {
+	order    int
+	selector string
+	css      string
+}
+
+var spacingPattern = regexp.MustCompile(`^(-?)(p|px|py|pt|pr|pb|pl|m|mx|my|mt|mr|mb|ml|gap|gap-x|gap-y|space-y|space-x|w|h)-([0-9.]+|px)$`)
+var utilityPrefix = regexp.MustCompile(`^-?(text|bg|border|divide|ring|p[xytrbl]?|m[xytrbl]?|gap|space|w|h|min-w|max-w|grid|col|rounded|shadow|font|flex|items|justify|overflow|opacity|duration|tracking)-`)
+var colorPattern = regexp.MustCompile(`^(text|bg|border|divide|ring)-([a-z]+)-(\d+)$`)
+
+// resolve returns the declarations of a utility class without variant
+func resolve(class string) (rule, bool) {
+	for i, s := range static {
+		if s.class == class {
+			return rule{order: orderStatic + i, css: s.css}, true
+		}
+	}
+
+	switch class {
+	case "text-white", "bg-white", "border-white":
+		return colorRule(strings.TrimSuffix(class, "-white"), "#ffffff"), true
+	case "text-black", "bg-black":
+		return colorRule(strings.TrimSuffix(class, "-black"), "#000000"), true
+	case "divide-y":
+		return rule{order: orderSides, selector: " > :not([hidden]) ~ :not([hidden])", css: "border-top-width: 1px; border-bottom-width: 0px"}, true
+	}
+
+	if size, ok := strings.CutPrefix(class, "text-"); ok {
+		if css, ok := fontSizes[size]; ok {
+			return rule{order: orderSizing, css: css}, true
+		}
+	}
+	if size, ok := strings.CutPrefix(class, "max-w-"); ok {
+		if width, ok := maxWidths[size]; ok {
+			return rule{order: orderSizing, css: "max-width: " + width}, true
+		}
+	}
+	if n, ok := strings.CutPrefix(class, "grid-cols-"); ok {
+		if _, err := strconv.Atoi(n); err == nil {
+			return rule{order: orderGrid, css: fmt.Sprintf("grid-template-columns: repeat(%s, minmax(0, 1fr))", n)}, true
+		}
+	}
+	if n, ok := strings.CutPrefix(class, "col-span-"); ok {
+		if _, err := strconv.Atoi(n); err == nil {
+			return rule{order: orderGrid, css: fmt.Sprintf("grid-column: span %s / span %s", n, n)}, true
+		}
+	}
+
+	if m := colorPattern.FindStringSubmatch(class); m != nil {
+		colors, ok := palette[m[2]]
+		if !ok {
+			return rule{}, false
+		}
+		for i, shade := range shades {
+			if shade == m[3] {
+				return colorRule(m[1], colors[i]), true
+			}
+		}
+		return rule{}, false
+	}
+
+	if m := spacingPattern.FindStringSubmatch(class); m != nil {
+		value := "1px"
+		if m[3] != "px" {
+			n, err := strconv.ParseFloat(m[3], 64)
+			if err != nil {
+				return rule{}, false
+			}
+			value = strconv.FormatFloat(n/4, 'f', -1, 64) + "rem"
+			if n == 0 {
+				value = "0px"
+			}
+		}
+		if m[1] == "-" {
+			value = "-" + value
+		}
+		return spacingRule(m[2], value), true
+	}
+
+	return rule{}, false
+}
+
+func colorRule(kind, color string) rule {
+	switch kind {
+	case "text":
+		return rule{order: orderColor, css: "color: " + color}
+	case "bg":
+		return rule{order: orderColor, css: "background-color: " + color}
+	case "divide":
+		return rule{order: orderColor, selector: " > :not([hidden]) ~ :not([hidden])", css: "border-color: " + color}
+	case "ring":
+		return rule{order: orderColor, css: "--tw-ring-color: " + color}
+	default:
+		return rule{order: orderColor, css: "border-color: " + color}
+	}
+}
+
+func spacingRule(kind, value string) rule {
+	sides := map[string][]string{
+		"p": {"padding"}, "px": {"padding-left", "padding-right"}, "py": {"padding-top", "padding-bottom"},
+		"pt": {"padding-top"}, "pr": {"padding-right"}, "pb": {"padding-bottom"}, "pl": {"padding-left"},
+		"m": {"margin"}, "mx": {"margin-left", "margin-right"}, "my": {"margin-top", "margin-bottom"},
+		"mt": {"margin-top"}, "mr": {"margin-right"}, "mb": {"margin-bottom"}, "ml": {"margin-left"},
+		"gap": {"gap"}, "gap-x": {"column-gap"}, "gap-y": {"row-gap"},
+		"w": {"width"}, "h": {"height"},
+	}
+
+	switch kind {
+	case "space-y":
+		return rule{order: orderSpacing, selector: " > :not([hidden]) ~ :not([hidden])", css: "margin-top: " + value}
+	case "space-x":
+		return rule{order: orderSpacing, selector: " > :not([hidden]) ~ :not([hidden])", css: "margin-left: " + value}
+	}
+
+	// Shorthands come first so that a single side overrides them
+	order := orderSides
+	switch kind {
+	case "p", "m", "gap", "w", "h":
+		order = orderSpacing
+	case "px", "py", "mx", "my", "gap-x", "gap-y":
+		order = orderSpacing + 500
+	}
+
+	declarations := make([]string, len(sides[kind]))
+	for i, property := range sides[kind] {
+		declarations[i] = property + ": " + value
+	}
+	return rule{order: order, css: strings.Join(declarations, "; ")}
+}
+
+// escape escapes a class name for use in a selector
+func escape(class string) string {
+	replacer := strings.NewReplacer(":", `\:`, ".", `\.`, "/", `\/`)
+	return "." + replacer.Replace(class)
+}
+
+const preflight = `*, ::before, ::after { box-sizing: border-box; border-width: 0; border-style: solid; border-color: #e5e7eb; }
+html { line-height: 1.5; -webkit-text-size-adjust: 100%; tab-size: 4; font-family: ui-sans-serif, system-ui, sans-serif, "Apple Color Emoji", "Segoe UI Emoji", "Segoe UI Symbol", "Noto Color Emoji"; }
+body { margin: 0; line-height: inherit; }
+h1, h2, h3, h4, h5, h6 { font-size: inherit; font-weight: inherit; }
+a { color: inherit; text-decoration: inherit; }
+b, strong { font-weight: bolder; }
+code, kbd, samp, pre { font-family: ui-monospace, SFMono-Regular, Menlo, Monaco, Consolas, "Liberation Mono", "Courier New", monospace; font-size: 1em; }
+table { text-indent: 0; border-color: inherit; border-collapse: collapse; }
+button, input, optgroup, select, textarea { font-family: inherit; font-size: 100%; font-weight: inherit; line-height: inherit; color: inherit; margin: 0; padding: 0; }
+button, select { text-transform: none; }
+button, [type='button'], [type='reset'], [type='submit'] { -webkit-appearance: button; background-color: transparent; background-image: none; }
+button, [role="button"] { cursor: pointer; }
+blockquote, dl, dd, h1, h2, h3, h4, h5, h6, hr, figure, p, pre { margin: 0; }
+ol, ul { list-style: none; margin: 0; padding: 0; }
+img, svg, video, canvas, audio, iframe, embed, object { display: block; vertical-align: middle; }
+img, video { max-width: 100%; height: auto; }
+[hidden] { display: none; }
+`
+
+func main() {
+	files, err := filepath.Glob("../*/*template*.go")
+	if err != nil {
+		fail(err)
+	}
+
+	classAttr := regexp.MustCompile(`class="([^"]*)"`)
+	classList := regexp.MustCompile(`classList\.(?:add|remove|toggle)\(([^)]*)\)`)
+	quoted := regexp.MustCompile(`'([^']+)'`)
+	template := regexp.MustCompile(`\{\{.*?\}\}`)
+
+	classes := make(map[string]bool)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			fail(err)
+		}
+		// Template actions are dropped, the classes between them are kept,
+		// e.g. {{if .X}}hidden{{end}}
+		source := template.ReplaceAllString(string(data), " ")
+		for _, m := range classAttr.FindAllStringSubmatch(source, -1) {
+			for _, class := range strings.Fields(m[1]) {
+				classes[class] = true
+			}
+		}
+		for _, m := range classList.FindAllStringSubmatch(source, -1) {
+			for _, q := range quoted.FindAllStringSubmatch(m[1], -1) {
+				classes[q[1]] = true
+			}
+		}
+	}
+
+	type generated struct {
+		rule
+		class string
+		media string
+		state string
+	}
+
+	var rules []generated
+	var unknown []string
+	for class := range classes {
+		base := class
+		media, state := "", ""
+		for {
+			variant, rest, found := strings.Cut(base, ":")
+			if !found {
+				break
+			}
+			switch {
+			case variant == "hover" || variant == "focus":
+				state = ":" + variant
+			case variant == "dark":
+				media = "(prefers-color-scheme: dark)"
+			case breakpoints[variant] != "":
+				media = "(min-width: " + breakpoints[variant] + ")"
+			default:
+				unknown = append(unknown, class)
+			}
+			base = rest
+		}
+
+		r, ok := resolve(base)
+		if !ok {
+			// Custom classes are defined in the templates themselves
+			if class != base || utilityPrefix.MatchString(base) {
+				unknown = append(unknown, class)
+			}
+			continue
+		}
+		rules = append(rules, generated{rule: r, class: class, media: media, state: state})
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].media != rules[j].media {
+			return mediaOrder(rules[i].media) < mediaOrder(rules[j].media)
+		}
+		if (rules[i].state == "") != (rules[j].state == "") {
+			return rules[i].state == ""
+		}
+		if rules[i].order != rules[j].order {
+			return rules[i].order < rules[j].order
+		}
+		return rules[i].class < rules[j].class
+	})
+
+	var out strings.Builder
+	out.WriteString("/* Code generated by gen_tailwind.go from the report templates; DO NOT EDIT. */\n")
+	out.WriteString("/* Tailwind CSS v3 preflight and utilities, https://tailwindcss.com, MIT License */\n")
+	out.WriteString(preflight)
+
+	media := ""
+	for _, r := range rules {
+		if r.media != media {
+			if media != "" {
+				out.WriteString("}\n")
+			}
+			media = r.media
+			fmt.Fprintf(&out, "@media %s {\n", media)
+		}
+		indent := ""
+		if media != "" {
+			indent = "  "
+		}
+		fmt.Fprintf(&out, "%s%s%s%s { %s; }\n", indent, escape(r.class), r.state, r.selector, r.css)
+	}
+	if media != "" {
+		out.WriteString("}\n")
+	}
+
+	if err := os.WriteFile("tailwind.css", []byte(out.String()), 0644); err != nil {
+		fail(err)
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		fail(fmt.Errorf("unknown Tailwind classes, add them to gen_tailwind.go: %s", strings.Join(unknown, ", ")))
+	}
+}
+
+// mediaOrder orders media queries like Tailwind: dark mode first, then the
+// breakpoints from the smallest to the largest
+func mediaOrder(media string) string {
+	width, ok := strings.CutPrefix(media, "(min-width: ")
+	if !ok {
+		return media
+	}
+	return fmt.Sprintf("~%08s", strings.TrimSuffix(width, ")"))
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "gen_tailwind:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,248 @@
+// Package bundle packs benchmarks into portable .tar.gz bundles, with a
+// manifest holding the checksum of every file, and unpacks them
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile is the manifest of a bundle, the first file of the archive
+const ManifestFile = "manifest.yml"
+
+// Version is increased whenever the layout of bundles changes
+const Version = 1
+
+// Manifest describes a bundle. Every file of the archive is listed in Files
+// with its path relative to the bundle, <benchmark>/<file>
+type Manifest struct {
+	Version    int       `yaml:"Version"`
+	CreatedAt  time.Time `yaml:"CreatedAt"`
+	Benchmarks []string  `yaml:"Benchmarks"`
+	Files      []File    `yaml:"Files"`
+}
+
+// File is a file of a bundle
+type File struct {
+	Path   string `yaml:"Path"`
+	Size   int64  `yaml:"Size"`
+	SHA256 string `yaml:"SHA256"`
+}
+
+// Write packs the folders of the benchmarks into a bundle written to w
+func Write(w io.Writer, folder string, names []string) (Manifest, error) {
+	manifest := Manifest{Version: Version, CreatedAt: time.Now(), Benchmarks: names}
+	for _, name := range names {
+		root := filepath.Join(folder, name)
+		err := filepath.WalkDir(root, func(file string, entry os.DirEntry, err error) error {
+			if err != nil || !entry.Type().IsRegular() {
+				return err
+			}
+			relative, err := filepath.Rel(folder, file)
+			if err != nil {
+				return err
+			}
+			size, sum, err := checksum(file)
+			if err != nil {
+				return err
+			}
+			manifest.Files = append(manifest.Files, File{Path: filepath.ToSlash(relative), Size: size, SHA256: sum})
+			return nil
+		})
+		if err != nil {
+			return Manifest{}, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+	}
+
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to encode %s: %w", ManifestFile, err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	header := &tar.Header{Name: ManifestFile, Mode: 0644, Size: int64(len(data)), ModTime: manifest.CreatedAt}
+	if err := tw.WriteHeader(header); err != nil {
+		return Manifest{}, fmt.Errorf("failed to write the bundle: %w", err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return Manifest{}, fmt.Errorf("failed to write the bundle: %w", err)
+	}
+	for _, file := range manifest.Files {
+		if err := writeFile(tw, filepath.Join(folder, filepath.FromSlash(file.Path)), file); err != nil {
+			return Manifest{}, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return Manifest{}, fmt.Errorf("failed to write the bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return Manifest{}, fmt.Errorf("failed to write the bundle: %w", err)
+	}
+	return manifest, nil
+}
+
+// writeFile adds a file to the archive, with its permissions and
+// modification time, which dates benchmarks without metadata
+func writeFile(tw *tar.Writer, source string, file File) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() != file.Size {
+		return fmt.Errorf("%s changed while exporting", file.Path)
+	}
+	header := &tar.Header{Name: file.Path, Mode: int64(info.Mode().Perm()), Size: file.Size, ModTime: info.ModTime()}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write the bundle: %w", err)
+	}
+	if _, err := io.CopyN(tw, in, file.Size); err != nil {
+		return fmt.Errorf("failed to write %s to the bundle: %w", file.Path, err)
+	}
+	return nil
+}
+
+// Extract unpacks a bundle into dir, which must be empty, and returns its
+// manifest. Every file is checked against the checksums of the manifest,
+// and the bundle is rejected if a file is missing, altered or not listed
+func Extract(r io.Reader, dir string) (Manifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("not a bundle: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	header, err := tr.Next()
+	if err != nil || header.Name != ManifestFile {
+		return Manifest{}, fmt.Errorf("not a bundle: %s is missing", ManifestFile)
+	}
+	data, err := io.ReadAll(tr)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("failed to read %s: %w", ManifestFile, err)
+	}
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return Manifest{}, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+	if manifest.Version > Version {
+		return Manifest{}, fmt.Errorf("the bundle has version %d, this masbench reads up to version %d", manifest.Version, Version)
+	}
+
+	for _, name := range manifest.Benchmarks {
+		if name == "" || name != path.Base(name) || strings.HasPrefix(name, ".") {
+			return Manifest{}, fmt.Errorf("invalid benchmark name in the manifest: %q", name)
+		}
+	}
+	files := make(map[string]File, len(manifest.Files))
+	for _, file := range manifest.Files {
+		if err := checkPath(file.Path, manifest.Benchmarks); err != nil {
+			return Manifest{}, err
+		}
+		files[file.Path] = file
+	}
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Manifest{}, fmt.Errorf("failed to read the bundle: %w", err)
+		}
+		// Folders are created along with their files
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+		file, ok := files[header.Name]
+		if !ok || header.Typeflag != tar.TypeReg {
+			return Manifest{}, fmt.Errorf("%s is not listed in the manifest", header.Name)
+		}
+		delete(files, header.Name)
+		if err := extractFile(tr, header, filepath.Join(dir, filepath.FromSlash(file.Path)), file); err != nil {
+			return Manifest{}, err
+		}
+	}
+	for missing := range files {
+		return Manifest{}, fmt.Errorf("%s is missing from the bundle", missing)
+	}
+
+	// Benchmarks without files, such as interrupted runs, are kept
+	for _, name := range manifest.Benchmarks {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0755); err != nil {
+			return Manifest{}, err
+		}
+	}
+	return manifest, nil
+}
+
+// checkPath returns an error unless a file of the manifest is inside the
+// folder of one of its benchmarks
+func checkPath(file string, benchmarks []string) error {
+	name, _, _ := strings.Cut(file, "/")
+	if !fs.ValidPath(file) || path.Clean(file) != file || !slices.Contains(benchmarks, name) || name == file {
+		return fmt.Errorf("invalid path in the manifest: %s", file)
+	}
+	return nil
+}
+
+// extractFile writes a file of the archive to destination and checks it
+// against the manifest
+func extractFile(r io.Reader, header *tar.Header, destination string, file File) error {
+	if err := os.MkdirAll(filepath.Dir(destination), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(destination, os.O_CREATE|os.O_EXCL|os.O_WRONLY, fs.FileMode(header.Mode).Perm()|0600)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(out, hash), io.LimitReader(r, file.Size+1))
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", file.Path, err)
+	}
+	if size != file.Size || hex.EncodeToString(hash.Sum(nil)) != file.SHA256 {
+		return fmt.Errorf("checksum mismatch for %s, the bundle is corrupted", file.Path)
+	}
+	return os.Chtimes(destination, header.ModTime, header.ModTime)
+}
+
+// checksum returns the size and SHA-256 of a file
+func checksum(file string) (int64, string, error) {
+	in, err := os.Open(file)
+	if err != nil {
+		return 0, "", err
+	}
+	defer in.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, in)
+	if err != nil {
+		return 0, "", err
+	}
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
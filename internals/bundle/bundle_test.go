@@ -0,0 +1,195 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// entry is a file of a hand-made bundle
+type entry struct {
+	name    string
+	content string
+}
+
+// makeBundle writes a bundle holding the files, with a manifest listing them
+// with their checksums. edit may alter the manifest before it is written
+func makeBundle(t *testing.T, benchmarks []string, files []entry, edit func(*Manifest)) []byte {
+	t.Helper()
+	manifest := Manifest{Version: Version, Benchmarks: benchmarks}
+	for _, file := range files {
+		sum := sha256.Sum256([]byte(file.content))
+		manifest.Files = append(manifest.Files, File{Path: file.name, Size: int64(len(file.content)), SHA256: hex.EncodeToString(sum[:])})
+	}
+	if edit != nil {
+		edit(&manifest)
+	}
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, file := range append([]entry{{ManifestFile, string(data)}}, files...) {
+		header := &tar.Header{Name: file.name, Mode: 0644, Size: int64(len(file.content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(file.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestWriteExtract(t *testing.T) {
+	folder := t.TempDir()
+	for name, content := range map[string]string{
+		"astar/astar_results.csv": "LevelName,Solved\nL00,Yes\n",
+		"astar/logs/L00.log":      "log",
+	} {
+		path := filepath.Join(folder, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := Write(&buf, folder, []string{"astar"}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	dir := t.TempDir()
+	manifest, err := Extract(&buf, dir)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(manifest.Files) != 2 {
+		t.Errorf("manifest lists %d files, want 2", len(manifest.Files))
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "astar", "logs", "L00.log"))
+	if err != nil || string(data) != "log" {
+		t.Errorf("extracted log = %q, %v, want %q", data, err, "log")
+	}
+}
+
+func TestExtractRejects(t *testing.T) {
+	results := entry{"astar/astar_results.csv", "LevelName,Solved\n"}
+	tests := []struct {
+		name       string
+		benchmarks []string
+		files      []entry
+		edit       func(*Manifest)
+		err        string
+	}{
+		{
+			name:       "parent path",
+			benchmarks: []string{"astar"},
+			files:      []entry{{"../evil.sh", "x"}},
+			err:        "invalid path in the manifest: ../evil.sh",
+		},
+		{
+			name:       "parent path inside a benchmark",
+			benchmarks: []string{"astar"},
+			files:      []entry{{"astar/../../evil.sh", "x"}},
+			err:        "invalid path in the manifest",
+		},
+		{
+			name:       "absolute path",
+			benchmarks: []string{"astar"},
+			files:      []entry{{"/tmp/evil.sh", "x"}},
+			err:        "invalid path in the manifest: /tmp/evil.sh",
+		},
+		{
+			name:       "path outside the benchmarks",
+			benchmarks: []string{"astar"},
+			files:      []entry{{"other/file", "x"}},
+			err:        "invalid path in the manifest: other/file",
+		},
+		{
+			name:       "invalid benchmark name",
+			benchmarks: []string{".."},
+			err:        `invalid benchmark name in the manifest: ".."`,
+		},
+		{
+			name:       "checksum mismatch",
+			benchmarks: []string{"astar"},
+			files:      []entry{results},
+			edit:       func(m *Manifest) { m.Files[0].SHA256 = strings.Repeat("0", 64) },
+			err:        "checksum mismatch for astar/astar_results.csv",
+		},
+		{
+			name:       "size mismatch",
+			benchmarks: []string{"astar"},
+			files:      []entry{results},
+			edit:       func(m *Manifest) { m.Files[0].Size-- },
+			err:        "checksum mismatch for astar/astar_results.csv",
+		},
+		{
+			name:       "file not listed",
+			benchmarks: []string{"astar"},
+			files:      []entry{results},
+			edit:       func(m *Manifest) { m.Files = nil },
+			err:        "astar/astar_results.csv is not listed in the manifest",
+		},
+		{
+			name:       "file missing",
+			benchmarks: []string{"astar"},
+			edit: func(m *Manifest) {
+				m.Files = []File{{Path: "astar/astar.md", Size: 1, SHA256: strings.Repeat("0", 64)}}
+			},
+			err: "astar/astar.md is missing from the bundle",
+		},
+		{
+			name:       "newer version",
+			benchmarks: []string{"astar"},
+			edit:       func(m *Manifest) { m.Version = Version + 1 },
+			err:        "this masbench reads up to version",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := makeBundle(t, tt.benchmarks, tt.files, tt.edit)
+			parent := t.TempDir()
+			dir := filepath.Join(parent, "bundle")
+			if err := os.Mkdir(dir, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := Extract(bytes.NewReader(data), dir)
+			if err == nil {
+				t.Fatalf("Extract succeeded, want an error containing %q", tt.err)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Extract error = %q, want it to contain %q", err, tt.err)
+			}
+			if _, err := os.Stat(filepath.Join(parent, "evil.sh")); err == nil {
+				t.Errorf("Extract wrote outside %s", dir)
+			}
+		})
+	}
+}
+
+func TestExtractRejectsNonBundles(t *testing.T) {
+	if _, err := Extract(strings.NewReader("not a bundle"), t.TempDir()); err == nil || !strings.Contains(err.Error(), "not a bundle") {
+		t.Errorf("Extract error = %v, want not a bundle", err)
+	}
+}
@@ -0,0 +1,212 @@
+package checker
+
+import (
+	"fmt"
+	"masbench/internals/comparator"
+	"masbench/internals/models"
+	"strings"
+)
+
+// Check evaluates the rules of cfg on a comparison of the candidate
+// (benchmark1) against the baseline (benchmark2). Allowlisted metrics of a
+// level are treated as if the candidate matched the baseline on them
+func Check(report comparator.ComparisonReport, cfg models.CheckConfig, timeout int) (Result, error) {
+	result := Result{
+		Candidate: report.Benchmark1Name,
+		Baseline:  report.Benchmark2Name,
+		Rules:     []RuleResult{},
+		Allowed:   []string{},
+	}
+
+	rules := make([]Rule, 0, len(cfg.Rules))
+	for _, text := range cfg.Rules {
+		rule, err := ParseRule(text)
+		if err != nil {
+			return Result{}, err
+		}
+		rules = append(rules, rule)
+	}
+
+	allow, err := parseAllowlist(cfg.Allow)
+	if err != nil {
+		return Result{}, err
+	}
+
+	levels := make([]comparator.LevelComparison, len(report.Levels))
+	copy(levels, report.Levels)
+	for i := range levels {
+		metrics, ok := allow[levels[i].LevelName]
+		if !ok {
+			continue
+		}
+		applyAllowlist(&levels[i], metrics)
+		result.Allowed = append(result.Allowed, levels[i].LevelName)
+	}
+	result.Levels = levels
+	aggregates := comparator.ComputeAggregates(levels, report.MetricNames, timeout)
+
+	for _, rule := range rules {
+		value, baseline, matching, reason := evaluate(rule.Quantity, levels, aggregates)
+		limit := rule.Limit
+		if rule.VsBaseline {
+			limit = baseline
+		}
+		result.Rules = append(result.Rules, RuleResult{
+			Rule:   rule.Text,
+			Value:  value,
+			Limit:  limit,
+			Count:  isCount(rule.Quantity),
+			Passed: reason == "" && compare(value, rule.Op, limit),
+			Levels: matching,
+			Reason: reason,
+		})
+	}
+
+	return result, nil
+}
+
+// parseAllowlist validates the allowlist, returning the allowed metric
+// columns of every level, "solved" and "all" allow the whole level
+func parseAllowlist(allow map[string][]string) (map[string]map[string]bool, error) {
+	parsed := make(map[string]map[string]bool, len(allow))
+	for level, names := range allow {
+		metrics := make(map[string]bool)
+		for _, name := range names {
+			if strings.EqualFold(name, AllowAll) || strings.EqualFold(name, QuantitySolved) {
+				metrics[AllowAll] = true
+				continue
+			}
+			column, err := models.MetricColumn(name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid allowlist of level %s: %w", level, err)
+			}
+			metrics[column] = true
+		}
+		parsed[level] = metrics
+	}
+	return parsed, nil
+}
+
+// applyAllowlist makes the candidate match the baseline on the allowed
+// metrics of a level, or on the whole level
+func applyAllowlist(level *comparator.LevelComparison, allowed map[string]bool) {
+	if allowed[AllowAll] {
+		level.Solved.Solved1 = level.Solved.Solved2
+		level.Solved.Missing1 = level.Solved.Missing2
+		level.Solved.Changed = false
+		level.Solved.Status = comparator.StatusUnchanged
+		if level.Solved.Missing2 {
+			level.Solved.Status = comparator.StatusMissing
+		}
+		if level.Presence == comparator.PresenceOnly2 {
+			level.Presence = comparator.PresenceBoth
+		}
+	}
+
+	for i, metric := range level.Metrics() {
+		if !allowed[AllowAll] && !allowed[models.MetricColumns[i]] {
+			continue
+		}
+		metric.Value1 = metric.Value2
+		metric.Missing1 = metric.Missing2
+		metric.Diff = 0
+		metric.DiffPct = 0
+		metric.IsImprovement = false
+		metric.Status = comparator.StatusUnchanged
+		if metric.Missing2 {
+			metric.Status = comparator.StatusMissing
+		}
+	}
+}
+
+// evaluate returns the value of a quantity for the candidate, its value for
+// the baseline, and for count quantities the levels that were counted. The
+// reason is set when the quantity has no data to be computed from, its value
+// would otherwise make rules pass vacuously
+func evaluate(quantity string, levels []comparator.LevelComparison, aggregates comparator.Aggregates) (float64, float64, []string, string) {
+	column, sub, _ := splitQuantity(quantity)
+	var matching []string
+
+	switch sub {
+	case QuantitySolved:
+		solved1, solved2 := 0, 0
+		for _, level := range levels {
+			if level.Solved.Solved1 == models.SolvedYes && !level.Solved.Missing1 {
+				solved1++
+			}
+			if level.Solved.Solved2 == models.SolvedYes && !level.Solved.Missing2 {
+				solved2++
+			}
+		}
+		return float64(solved1), float64(solved2), nil, ""
+
+	case QuantityNewlyUnsolved, QuantityNewlySolved:
+		status := comparator.StatusRegression
+		if sub == QuantityNewlySolved {
+			status = comparator.StatusImprovement
+		}
+		for _, level := range levels {
+			if level.Presence == comparator.PresenceBoth && level.Solved.Status == status {
+				matching = append(matching, level.LevelName)
+			}
+		}
+		return float64(len(matching)), 0, matching, ""
+
+	case QuantityMissing:
+		for _, level := range levels {
+			if level.Presence == comparator.PresenceOnly2 {
+				matching = append(matching, level.LevelName)
+			}
+		}
+		return float64(len(matching)), 0, matching, ""
+
+	case QuantityPAR2:
+		if aggregates.PAR2Value2 <= 0 {
+			return 0, 1, nil, "the baseline has no PAR-2 score to compare to"
+		}
+		return aggregates.PAR2Ratio, 1, nil, ""
+
+	case QuantityRegressions:
+		for i := range levels {
+			level := &levels[i]
+			if level.Solved.Solved1 != models.SolvedYes || level.Solved.Solved2 != models.SolvedYes {
+				continue
+			}
+			for j, metric := range level.Metrics() {
+				if models.MetricColumns[j] == column && metric.Status == comparator.StatusRegression {
+					matching = append(matching, level.LevelName)
+				}
+			}
+		}
+		return float64(len(matching)), 0, matching, ""
+	}
+
+	for _, aggregate := range aggregates.Metrics {
+		if aggregate.Metric != column {
+			continue
+		}
+		switch sub {
+		case QuantityGeoMean, QuantityMedian, QuantityTotal:
+			if aggregate.RatioLevels == 0 {
+				return 0, 1, nil, fmt.Sprintf("no level solved by both benchmarks to compute a %s ratio over", column)
+			}
+		case QuantityImproved, QuantityRegressed:
+			if aggregate.Levels == 0 {
+				return 0, 0, nil, fmt.Sprintf("no level solved by both benchmarks with %s values", column)
+			}
+		}
+		switch sub {
+		case QuantityGeoMean:
+			return aggregate.GeoMeanRatio, 1, nil, ""
+		case QuantityMedian:
+			return aggregate.MedianRatio, 1, nil, ""
+		case QuantityTotal:
+			return aggregate.TotalRatio, 1, nil, ""
+		case QuantityImproved:
+			return aggregate.ImprovedFraction, 0, nil, ""
+		case QuantityRegressed:
+			return aggregate.RegressedFraction, 0, nil, ""
+		}
+	}
+	return 0, 0, nil, fmt.Sprintf("no %s values to compare", column)
+}
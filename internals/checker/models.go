@@ -0,0 +1,51 @@
+package checker
+
+import "masbench/internals/comparator"
+
+// Rule is a parsed check rule of the form "<quantity> <op> <limit>", where
+// limit is a number or "baseline"
+type Rule struct {
+	Text       string
+	Quantity   string
+	Op         string
+	Limit      float64
+	VsBaseline bool // the limit is the value of the baseline itself
+}
+
+// RuleResult is the outcome of one rule
+type RuleResult struct {
+	Rule   string
+	Value  float64 // value of the quantity for the candidate
+	Limit  float64 // value it was compared to
+	Count  bool    // the quantity is a number of levels rather than a ratio
+	Passed bool
+	Levels []string // levels making up a count quantity
+	// Reason tells why the quantity could not be computed, such as a ratio
+	// without levels solved by both benchmarks. The rule then fails
+	Reason string
+}
+
+// Result is the outcome of checking a candidate against a baseline
+type Result struct {
+	Candidate string
+	Baseline  string
+	Rules     []RuleResult
+	Allowed   []string                     // allowlisted levels present in the comparison
+	Levels    []comparator.LevelComparison // compared levels, with the allowlist applied
+}
+
+// Violations returns the rules that failed
+func (r Result) Violations() []RuleResult {
+	var violations []RuleResult
+	for _, rule := range r.Rules {
+		if !rule.Passed {
+			violations = append(violations, rule)
+		}
+	}
+	return violations
+}
+
+// Passed reports whether every rule passed
+func (r Result) Passed() bool {
+	return len(r.Violations()) == 0
+}
@@ -0,0 +1,123 @@
+package checker
+
+import (
+	"fmt"
+	"masbench/internals/models"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Quantities not tied to a metric
+const (
+	QuantitySolved        = "solved"
+	QuantityNewlyUnsolved = "newly-unsolved"
+	QuantityNewlySolved   = "newly-solved"
+	QuantityMissing       = "missing"
+	QuantityPAR2          = "par2"
+)
+
+// Per-metric quantities, used as "<metric>.<quantity>", e.g. "time.geomean"
+const (
+	QuantityGeoMean     = "geomean"
+	QuantityMedian      = "median"
+	QuantityTotal       = "total"
+	QuantityImproved    = "improved"
+	QuantityRegressed   = "regressed"
+	QuantityRegressions = "regressions"
+)
+
+// AllowAll allowlists every metric of a level
+const AllowAll = "all"
+
+var metricQuantities = []string{QuantityGeoMean, QuantityMedian, QuantityTotal, QuantityImproved, QuantityRegressed, QuantityRegressions}
+
+var rulePattern = regexp.MustCompile(`^([A-Za-z0-9_.\-]+)\s*(<=|>=|==|!=|<|>)\s*(\S+)$`)
+
+// ParseRule parses a rule such as "time.geomean <= 1.05", "solved >= baseline"
+// or "no newly-unsolved levels", a shorthand for "newly-unsolved == 0"
+func ParseRule(text string) (Rule, error) {
+	trimmed := strings.TrimSpace(text)
+	if quantity, ok := strings.CutPrefix(trimmed, "no "); ok {
+		trimmed = strings.TrimSuffix(strings.TrimSpace(quantity), " levels") + " == 0"
+	}
+
+	match := rulePattern.FindStringSubmatch(trimmed)
+	if match == nil {
+		return Rule{}, fmt.Errorf("invalid rule %q, expected \"<quantity> <op> <value>\"", text)
+	}
+
+	rule := Rule{Text: text, Quantity: strings.ToLower(match[1]), Op: match[2]}
+	if _, _, err := splitQuantity(rule.Quantity); err != nil {
+		return Rule{}, fmt.Errorf("invalid rule %q: %w", text, err)
+	}
+
+	if strings.EqualFold(match[3], "baseline") {
+		if isCount(rule.Quantity) && rule.Quantity != QuantitySolved {
+			return Rule{}, fmt.Errorf("invalid rule %q: %s cannot be compared to the baseline", text, rule.Quantity)
+		}
+		rule.VsBaseline = true
+		return rule, nil
+	}
+
+	limit, err := strconv.ParseFloat(match[3], 64)
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid rule %q: %q is neither a number nor baseline", text, match[3])
+	}
+	rule.Limit = limit
+
+	return rule, nil
+}
+
+// splitQuantity returns the metric column and per-metric quantity of
+// quantities like "time.geomean", the metric is empty for the others
+func splitQuantity(quantity string) (string, string, error) {
+	metric, sub, found := strings.Cut(quantity, ".")
+	if !found {
+		switch quantity {
+		case QuantitySolved, QuantityNewlyUnsolved, QuantityNewlySolved, QuantityMissing, QuantityPAR2:
+			return "", quantity, nil
+		}
+		return "", "", fmt.Errorf("unknown quantity %q", quantity)
+	}
+
+	column, err := models.MetricColumn(metric)
+	if err != nil {
+		return "", "", err
+	}
+	for _, q := range metricQuantities {
+		if sub == q {
+			return column, sub, nil
+		}
+	}
+	return "", "", fmt.Errorf("unknown quantity %q, expected one of %s", sub, strings.Join(metricQuantities, ", "))
+}
+
+// isCount reports whether a quantity is a number of levels
+func isCount(quantity string) bool {
+	_, sub, _ := splitQuantity(quantity)
+	switch sub {
+	case QuantitySolved, QuantityNewlyUnsolved, QuantityNewlySolved, QuantityMissing, QuantityRegressions:
+		return true
+	}
+	return false
+}
+
+// compare applies the operator of a rule
+func compare(value float64, op string, limit float64) bool {
+	switch op {
+	case "<=":
+		return value <= limit
+	case ">=":
+		return value >= limit
+	case "<":
+		return value < limit
+	case ">":
+		return value > limit
+	case "==":
+		return value == limit
+	case "!=":
+		return value != limit
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package comparator
+
+import (
+	"masbench/internals/models"
+	"masbench/internals/utils"
+	"math"
+)
+
+// ComputeAggregates computes the headline figures of a comparison: ratios of
+// every metric over the levels solved by both benchmarks and the PAR-2 score
+// over the levels present in both
+func ComputeAggregates(levels []LevelComparison, metricNames []string, timeout int) Aggregates {
+	aggregates := Aggregates{}
+	penalty := 2 * float64(timeout)
+
+	for i := range levels {
+		level := &levels[i]
+		if level.Presence != PresenceBoth {
+			continue
+		}
+		aggregates.CommonLevels++
+		aggregates.PAR2Value1 += par2Cost(level.Solved.Solved1, level.Time.Value1, level.Time.Missing1, penalty)
+		aggregates.PAR2Value2 += par2Cost(level.Solved.Solved2, level.Time.Value2, level.Time.Missing2, penalty)
+
+		if level.Solved.Solved1 == models.SolvedYes && level.Solved.Solved2 == models.SolvedYes {
+			aggregates.SolvedBoth++
+		}
+	}
+
+	if aggregates.CommonLevels > 0 {
+		aggregates.PAR2Value1 /= float64(aggregates.CommonLevels)
+		aggregates.PAR2Value2 /= float64(aggregates.CommonLevels)
+	}
+	if aggregates.PAR2Value2 > 0 {
+		aggregates.PAR2Ratio = aggregates.PAR2Value1 / aggregates.PAR2Value2
+	}
+
+	for m, metricName := range metricNames {
+		aggregates.Metrics = append(aggregates.Metrics, aggregateMetric(levels, m, metricName))
+	}
+
+	return aggregates
+}
+
+// aggregateMetric computes the ratios of the metric at index m of
+// LevelComparison.Metrics over the levels solved by both benchmarks
+func aggregateMetric(levels []LevelComparison, m int, metricName string) AggregateMetric {
+	result := AggregateMetric{Metric: metricName}
+
+	var ratios []float64
+	logSum, total1, total2 := 0.0, 0.0, 0.0
+	improved, regressed := 0, 0
+
+	for i := range levels {
+		level := &levels[i]
+		if level.Presence != PresenceBoth || level.Solved.Solved1 != models.SolvedYes || level.Solved.Solved2 != models.SolvedYes {
+			continue
+		}
+		metric := level.Metrics()[m]
+		if metric.Missing1 || metric.Missing2 {
+			continue
+		}
+
+		result.Levels++
+		switch metric.Status {
+		case StatusImprovement:
+			improved++
+		case StatusRegression:
+			regressed++
+		}
+
+		total1 += metric.Value1
+		total2 += metric.Value2
+
+		if metric.Value1 <= 0 || metric.Value2 <= 0 {
+			continue
+		}
+		ratio := metric.Value1 / metric.Value2
+		ratios = append(ratios, ratio)
+		logSum += math.Log(ratio)
+	}
+
+	result.RatioLevels = len(ratios)
+	if len(ratios) > 0 {
+		result.GeoMeanRatio = math.Exp(logSum / float64(len(ratios)))
+		result.GeoMeanSpeedup = 1 / result.GeoMeanRatio
+		result.MedianRatio = utils.Median(ratios)
+	}
+	if total2 > 0 {
+		result.TotalRatio = total1 / total2
+	}
+	if result.Levels > 0 {
+		result.ImprovedFraction = float64(improved) / float64(result.Levels)
+		result.RegressedFraction = float64(regressed) / float64(result.Levels)
+	}
+
+	return result
+}
+
+// par2Cost is the PAR-2 cost of a single level: its time when solved,
+// twice the timeout otherwise
+func par2Cost(solved string, timeVal float64, missing bool, penalty float64) float64 {
+	if solved != models.SolvedYes || missing {
+		return penalty
+	}
+	return timeVal
+}
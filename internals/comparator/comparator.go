@@ -3,6 +3,8 @@ package comparator
 import (
 	"fmt"
 	"html/template"
+	"io"
+	"masbench/internals/assets"
 	"masbench/internals/models"
 	"masbench/internals/utils"
 	"os"
@@ -11,13 +13,17 @@ import (
 	"github.com/go-gota/gota/dataframe"
 )
 
-func GenerateHTMLReport(df1, df2 dataframe.DataFrame, name1, name2, outputPath string) error {
-	report := prepareComparisonData(df1, df2, name1, name2)
+// reportFuncs are the helper functions available to the report templates
+var reportFuncs = template.FuncMap{
+	"percent": func(fraction float64) float64 {
+		return fraction * 100
+	},
+	"styles": assets.Styles,
+	"script": assets.ScriptTag,
+}
 
-	tmpl, err := template.New("report").Parse(reportTemplate)
-	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
-	}
+func GenerateHTMLReport(df1, df2 dataframe.DataFrame, name1, name2, outputPath string, opts Options) error {
+	report := PrepareComparisonData(df1, df2, name1, name2, opts)
 
 	file, err := os.Create(outputPath)
 	if err != nil {
@@ -25,99 +31,224 @@ func GenerateHTMLReport(df1, df2 dataframe.DataFrame, name1, name2, outputPath s
 	}
 	defer file.Close()
 
-	if err := tmpl.Execute(file, report); err != nil {
+	return WriteHTML(file, report)
+}
+
+// WriteHTML renders the comparison report as an interactive HTML page
+func WriteHTML(w io.Writer, report ComparisonReport) error {
+	tmpl, err := template.New("report").Funcs(reportFuncs).Parse(reportTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	if err := tmpl.Execute(w, report); err != nil {
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
 	return nil
 }
 
-func prepareComparisonData(df1, df2 dataframe.DataFrame, name1, name2 string) ComparisonReport {
+// PrepareComparisonData compares df1 (benchmark1) against df2 (benchmark2)
+func PrepareComparisonData(df1, df2 dataframe.DataFrame, name1, name2 string, opts Options) ComparisonReport {
 	report := ComparisonReport{
 		Title:          "Benchmark Comparison Report",
 		Benchmark1Name: name1,
 		Benchmark2Name: name2,
 		GeneratedAt:    time.Now().Format("2006-01-02 15:04:05"),
-		MetricNames:    []string{models.ColGenerated, models.ColExplored, models.ColMemoryAlloc, models.ColTime, models.ColActions},
+		MetricNames:    models.MetricColumns,
+		// Empty rather than nil so the JSON export always has lists
+		OnlyInBenchmark1: []string{},
+		OnlyInBenchmark2: []string{},
 	}
 
-	df2Map := utils.ToMap(df2)
+	df1Map := utils.ToRowsMap(df1)
+	df2Map := utils.ToRowsMap(df2)
 
-	levelNames1 := df1.Col(models.ColLevelName).Records()
+	for _, levelName := range unionLevels(df1, df2) {
+		df1Data, in1 := df1Map[levelName]
+		df2Data, in2 := df2Map[levelName]
 
-	for i := 0; i < df1.Nrow(); i++ {
-		levelName := levelNames1[i]
 		levelComp := LevelComparison{
 			LevelName: levelName,
+			Presence:  PresenceBoth,
+		}
+
+		switch {
+		case !in2:
+			levelComp.Presence = PresenceOnly1
+			report.OnlyInBenchmark1 = append(report.OnlyInBenchmark1, levelName)
+		case !in1:
+			levelComp.Presence = PresenceOnly2
+			report.OnlyInBenchmark2 = append(report.OnlyInBenchmark2, levelName)
+		}
+
+		levelComp.Generated = compareColumn(df1Data, df2Data, models.ColGenerated, opts)
+		levelComp.Explored = compareColumn(df1Data, df2Data, models.ColExplored, opts)
+		levelComp.MemoryAlloc = compareColumn(df1Data, df2Data, models.ColMemoryAlloc, opts)
+		levelComp.Time = compareColumn(df1Data, df2Data, models.ColTime, opts)
+		levelComp.Actions = compareColumn(df1Data, df2Data, models.ColActions, opts)
+
+		solved1 := solvedStatus(df1Data)
+		solved2 := solvedStatus(df2Data)
+		levelComp.Solved = compareSolved(solved1, solved2, !in1, !in2)
+
+		// A level solved by only one benchmark is a win (or loss) on every
+		// metric, even if the unsolved side reported no values at all
+		if levelComp.Solved.Status == StatusImprovement || levelComp.Solved.Status == StatusRegression {
+			for _, metric := range levelComp.Metrics() {
+				metric.Status = levelComp.Solved.Status
+				metric.IsImprovement = levelComp.Solved.Status == StatusImprovement
+				if metric.Significance != "" {
+					metric.Significance = SignificantRegression
+					if metric.IsImprovement {
+						metric.Significance = SignificantImprovement
+					}
+				}
+			}
 		}
 
-		df2Data := df2Map[levelName]
-
-		levelComp.Generated = compareMetric(
-			utils.GetFloatFromDF(df1, i, models.ColGenerated),
-			utils.GetFloatFromMap(df2Data, models.ColGenerated),
-			true,
-		)
-
-		levelComp.Explored = compareMetric(
-			utils.GetFloatFromDF(df1, i, models.ColExplored),
-			utils.GetFloatFromMap(df2Data, models.ColExplored),
-			true,
-		)
-
-		levelComp.MemoryAlloc = compareMetric(
-			utils.GetFloatFromDF(df1, i, models.ColMemoryAlloc),
-			utils.GetFloatFromMap(df2Data, models.ColMemoryAlloc),
-			true,
-		)
-
-		levelComp.Time = compareMetric(
-			utils.GetFloatFromDF(df1, i, models.ColTime),
-			utils.GetFloatFromMap(df2Data, models.ColTime),
-			true,
-		)
-
-		levelComp.Actions = compareMetric(
-			utils.GetFloatFromDF(df1, i, models.ColActions),
-			utils.GetFloatFromMap(df2Data, models.ColActions),
-			true,
-		)
-
-		solved1 := utils.GetStringFromDF(df1, i, models.ColSolved)
-		solved2 := utils.GetStringFromMap(df2Data, models.ColSolved)
-		levelComp.Solved = compareSolved(solved1, solved2)
-
-		if solved1 == models.SolvedYes && solved2 == models.SolvedNo {
-			levelComp.Generated.Status = "improvement"
-			levelComp.Generated.IsImprovement = true
-			levelComp.Explored.Status = "improvement"
-			levelComp.Explored.IsImprovement = true
-			levelComp.MemoryAlloc.Status = "improvement"
-			levelComp.MemoryAlloc.IsImprovement = true
-			levelComp.Time.Status = "improvement"
-			levelComp.Time.IsImprovement = true
-			levelComp.Actions.Status = "improvement"
-			levelComp.Actions.IsImprovement = true
-		} else if solved1 == models.SolvedNo && solved2 == models.SolvedYes {
-			levelComp.Generated.Status = "regression"
-			levelComp.Generated.IsImprovement = false
-			levelComp.Explored.Status = "regression"
-			levelComp.Explored.IsImprovement = false
-			levelComp.MemoryAlloc.Status = "regression"
-			levelComp.MemoryAlloc.IsImprovement = false
-			levelComp.Time.Status = "regression"
-			levelComp.Time.IsImprovement = false
-			levelComp.Actions.Status = "regression"
-			levelComp.Actions.IsImprovement = false
+		if levelComp.Time.Runs1 > 1 || levelComp.Time.Runs2 > 1 {
+			report.HasRepeatedRuns = true
 		}
 
 		report.Levels = append(report.Levels, levelComp)
 	}
 
+	report.Significance = testOverallSignificance(report.Levels, report.MetricNames, opts.SignificanceLevel)
+	report.Aggregates = ComputeAggregates(report.Levels, report.MetricNames, opts.Timeout)
+
 	return report
 }
 
-func compareMetric(val1, val2 float64, lowerIsBetter bool) MetricComparison {
+// unionLevels returns every level name found in either dataframe, keeping
+// the order of df1 and appending the levels only present in df2
+func unionLevels(df1, df2 dataframe.DataFrame) []string {
+	seen := make(map[string]bool)
+	var levels []string
+
+	for _, df := range []dataframe.DataFrame{df1, df2} {
+		for _, level := range df.Col(models.ColLevelName).Records() {
+			if seen[level] {
+				continue
+			}
+			seen[level] = true
+			levels = append(levels, level)
+		}
+	}
+
+	return levels
+}
+
+// compareColumn compares a single metric of a level, marking the comparison
+// as missing if either side has no usable value for it. With repeated runs
+// the medians are compared and the classification is based on significance
+// instead of the tolerance
+func compareColumn(rows1, rows2 []map[string]string, colName string, opts Options) MetricComparison {
+	samples1 := utils.ResolveRuns(rows1).Samples(colName)
+	samples2 := utils.ResolveRuns(rows2).Samples(colName)
+
+	if len(samples1) == 0 || len(samples2) == 0 {
+		return MetricComparison{
+			Value1:   utils.Median(samples1),
+			Value2:   utils.Median(samples2),
+			Missing1: len(samples1) == 0,
+			Missing2: len(samples2) == 0,
+			Runs1:    len(samples1),
+			Runs2:    len(samples2),
+			Status:   StatusMissing,
+		}
+	}
+
+	repeated := len(samples1) > 1 && len(samples2) > 1
+	tolerance := opts.Tolerances[colName]
+	if repeated {
+		tolerance = models.Tolerance{}
+	}
+	comparison := compareMetric(utils.Median(samples1), utils.Median(samples2), true, tolerance)
+	comparison.Runs1 = len(samples1)
+	comparison.Runs2 = len(samples2)
+
+	if repeated {
+		_, comparison.PValue = mannWhitneyU(samples1, samples2)
+		comparison.CILow, comparison.CIHigh = bootstrapMedianDiffCI(samples1, samples2, 1-opts.SignificanceLevel)
+
+		switch {
+		case comparison.PValue >= opts.SignificanceLevel || comparison.Diff == 0:
+			comparison.Significance = Inconclusive
+			comparison.Status = StatusUnchanged
+			comparison.IsImprovement = false
+		case comparison.IsImprovement:
+			comparison.Significance = SignificantImprovement
+		default:
+			comparison.Significance = SignificantRegression
+		}
+	}
+
+	return comparison
+}
+
+// testOverallSignificance runs a Wilcoxon signed-rank test for every metric
+// over the levels solved by both benchmarks and compared on that metric
+func testOverallSignificance(levels []LevelComparison, metricNames []string, alpha float64) []MetricSignificance {
+	results := make([]MetricSignificance, 0, len(metricNames))
+
+	for i, metricName := range metricNames {
+		var diffs []float64
+		result := MetricSignificance{Metric: metricName}
+
+		for l := range levels {
+			level := &levels[l]
+			if level.Presence != PresenceBoth || level.Solved.Solved1 != models.SolvedYes || level.Solved.Solved2 != models.SolvedYes {
+				continue
+			}
+			metric := level.Metrics()[i]
+			if metric.Missing1 || metric.Missing2 {
+				continue
+			}
+			diffs = append(diffs, metric.Diff)
+			if metric.Diff < 0 {
+				result.Improved++
+			} else if metric.Diff > 0 {
+				result.Regressed++
+			}
+		}
+
+		result.WPlus, result.Levels, result.PValue = wilcoxonSignedRank(diffs)
+
+		// W+ sums the ranks of the levels where benchmark1 is higher, so a
+		// value below its expectation means benchmark1 is generally lower
+		expected := float64(result.Levels*(result.Levels+1)) / 4
+		switch {
+		case result.Levels == 0 || result.PValue >= alpha:
+			result.Label = Inconclusive
+		case result.WPlus < expected:
+			result.Label = SignificantImprovement
+		default:
+			result.Label = SignificantRegression
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// solvedStatus returns the solved value of a level over its runs
+func solvedStatus(rows []map[string]string) string {
+	if len(rows) == 0 {
+		return ""
+	}
+	if len(rows) == 1 {
+		return utils.GetStringFromMap(rows[0], models.ColSolved)
+	}
+
+	if utils.ResolveRuns(rows).Solved() {
+		return models.SolvedYes
+	}
+	return models.SolvedNo
+}
+
+func compareMetric(val1, val2 float64, lowerIsBetter bool, tolerance models.Tolerance) MetricComparison {
 	diff := val1 - val2
 	var diffPct float64
 	if val2 != 0 {
@@ -127,15 +258,15 @@ func compareMetric(val1, val2 float64, lowerIsBetter bool) MetricComparison {
 	var status string
 	var isImprovement bool
 
-	if diff == 0 {
-		status = "unchanged"
+	if diff == 0 || tolerance.Within(val1, val2) {
+		status = StatusUnchanged
 		isImprovement = false
 	} else if lowerIsBetter {
 		if diff < 0 {
-			status = "improvement"
+			status = StatusImprovement
 			isImprovement = true
 		} else {
-			status = "regression"
+			status = StatusRegression
 			isImprovement = false
 		}
 	}
@@ -150,22 +281,27 @@ func compareMetric(val1, val2 float64, lowerIsBetter bool) MetricComparison {
 	}
 }
 
-func compareSolved(solved1, solved2 string) SolvedComparison {
+func compareSolved(solved1, solved2 string, missing1, missing2 bool) SolvedComparison {
 	changed := solved1 != solved2
 	var status string
 
-	if !changed {
-		status = "unchanged"
+	if missing1 || missing2 {
+		status = StatusMissing
+		changed = false
+	} else if !changed {
+		status = StatusUnchanged
 	} else if solved1 == models.SolvedYes && solved2 == models.SolvedNo {
-		status = "improvement"
+		status = StatusImprovement
 	} else {
-		status = "regression"
+		status = StatusRegression
 	}
 
 	return SolvedComparison{
-		Solved1: solved1,
-		Solved2: solved2,
-		Changed: changed,
-		Status:  status,
+		Solved1:  solved1,
+		Solved2:  solved2,
+		Missing1: missing1,
+		Missing2: missing2,
+		Changed:  changed,
+		Status:   status,
 	}
 }
@@ -0,0 +1,17 @@
+package comparator
+
+import (
+	"io"
+	"masbench/internals/models"
+	"masbench/internals/utils"
+)
+
+// WriteJSON writes the comparison report as versioned JSON
+func WriteJSON(w io.Writer, report ComparisonReport) error {
+	return utils.WriteJSONReport(w, models.ReportKindComparison, report)
+}
+
+// WriteMultiJSON writes the multi comparison report as versioned JSON
+func WriteMultiJSON(w io.Writer, report MultiComparisonReport) error {
+	return utils.WriteJSONReport(w, models.ReportKindMultiComparison, report)
+}
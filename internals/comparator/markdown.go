@@ -0,0 +1,230 @@
+package comparator
+
+import (
+	"fmt"
+	"io"
+	"masbench/internals/markdown"
+	"masbench/internals/models"
+)
+
+// WriteMarkdown renders the comparison report as GitHub-flavored Markdown,
+// ready to be pasted in a pull request description
+func WriteMarkdown(w io.Writer, report ComparisonReport) error {
+	name1, name2 := markdown.Code(report.Benchmark1Name), markdown.Code(report.Benchmark2Name)
+	fmt.Fprintf(w, "## 📊 Benchmark Comparison: %s vs %s\n\n", name1, name2)
+	fmt.Fprintf(w, "_Generated by masbench on %s. Ratios are %s / %s, below 1 means %s is lower._\n\n",
+		report.GeneratedAt, name1, name2, name1)
+
+	solved1, solved2 := 0, 0
+	for _, level := range report.Levels {
+		if level.Solved.Solved1 == models.SolvedYes {
+			solved1++
+		}
+		if level.Solved.Solved2 == models.SolvedYes {
+			solved2++
+		}
+	}
+
+	agg := report.Aggregates
+	overview := markdown.NewTable(
+		markdown.Column{Header: ""},
+		markdown.Column{Header: name1, AlignRight: true},
+		markdown.Column{Header: name2, AlignRight: true},
+	)
+	overview.AddRow("Levels solved", fmt.Sprintf("%d", solved1), fmt.Sprintf("%d", solved2))
+	overview.AddRow("PAR-2", fmt.Sprintf("%.2fs", agg.PAR2Value1), fmt.Sprintf("%.2fs", agg.PAR2Value2))
+	if err := overview.Write(w); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "### Headline\n\n")
+	fmt.Fprintf(w, "%s PAR-2 ratio **%.3f** over %d common levels, %d solved by both.\n\n",
+		ratioMark(agg.PAR2Ratio), agg.PAR2Ratio, agg.CommonLevels, agg.SolvedBoth)
+
+	headline := markdown.NewTable(
+		markdown.Column{Header: "Metric"},
+		markdown.Column{Header: "Levels", AlignRight: true},
+		markdown.Column{Header: "Geo-mean speedup", AlignRight: true},
+		markdown.Column{Header: "Median ratio", AlignRight: true},
+		markdown.Column{Header: "Total ratio", AlignRight: true},
+		markdown.Column{Header: "Improved", AlignRight: true},
+		markdown.Column{Header: "Regressed", AlignRight: true},
+	)
+	for _, aggregate := range agg.Metrics {
+		speedup, medianRatio, total := "n/a", "n/a", "n/a"
+		if aggregate.RatioLevels > 0 {
+			speedup = fmt.Sprintf("%s %.3f×", ratioMark(aggregate.GeoMeanRatio), aggregate.GeoMeanSpeedup)
+			medianRatio = fmt.Sprintf("%.3f", aggregate.MedianRatio)
+		}
+		if aggregate.TotalRatio > 0 {
+			total = fmt.Sprintf("%.3f", aggregate.TotalRatio)
+		}
+		headline.AddRow(
+			aggregate.Metric,
+			fmt.Sprintf("%d", aggregate.Levels),
+			speedup,
+			medianRatio,
+			total,
+			fmt.Sprintf("%.1f%%", aggregate.ImprovedFraction*100),
+			fmt.Sprintf("%.1f%%", aggregate.RegressedFraction*100),
+		)
+	}
+	if err := headline.Write(w); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "### Significance\n\n")
+	significance := markdown.NewTable(
+		markdown.Column{Header: "Metric"},
+		markdown.Column{Header: "Levels", AlignRight: true},
+		markdown.Column{Header: "p-value", AlignRight: true},
+		markdown.Column{Header: "Result"},
+	)
+	for _, result := range report.Significance {
+		significance.AddRow(result.Metric, fmt.Sprintf("%d", result.Levels), fmt.Sprintf("%.4f", result.PValue), labelMark(result.Label)+" "+result.Label)
+	}
+	if err := significance.Write(w); err != nil {
+		return err
+	}
+
+	err := markdown.Details(w, fmt.Sprintf("Per-level results (%d levels)", len(report.Levels)), func(w io.Writer) error {
+		levels := markdown.NewTable(
+			markdown.Column{Header: "Level"},
+			markdown.Column{Header: "Solved"},
+			markdown.Column{Header: "Time", AlignRight: true},
+			markdown.Column{Header: "Actions", AlignRight: true},
+			markdown.Column{Header: "Generated", AlignRight: true},
+			markdown.Column{Header: "Explored", AlignRight: true},
+			markdown.Column{Header: "Memory", AlignRight: true},
+		)
+		for _, level := range report.Levels {
+			levels.AddRow(
+				markdown.Escape(level.LevelName),
+				statusMark(level.Solved.Status)+" "+formatSolved(level.Solved),
+				markdownCell(level.Time, "%.3f"),
+				markdownCell(level.Actions, "%.0f"),
+				markdownCell(level.Generated, "%.0f"),
+				markdownCell(level.Explored, "%.0f"),
+				markdownCell(level.MemoryAlloc, "%.2f"),
+			)
+		}
+		return levels.Write(w)
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(report.OnlyInBenchmark1) > 0 || len(report.OnlyInBenchmark2) > 0 {
+		return markdown.Details(w, "Levels present in only one benchmark", func(w io.Writer) error {
+			fmt.Fprintf(w, "- Only in %s: %s\n", name1, markdown.Escape(listOrNone(report.OnlyInBenchmark1)))
+			_, err := fmt.Fprintf(w, "- Only in %s: %s\n\n", name2, markdown.Escape(listOrNone(report.OnlyInBenchmark2)))
+			return err
+		})
+	}
+
+	return nil
+}
+
+// WriteMultiMarkdown renders the multi comparison report as GitHub-flavored Markdown
+func WriteMultiMarkdown(w io.Writer, report MultiComparisonReport) error {
+	fmt.Fprintf(w, "## 📊 Multi-Benchmark Comparison against %s\n\n", markdown.Code(report.BaselineName))
+	fmt.Fprintf(w, "_Generated by masbench on %s._\n\n", report.GeneratedAt)
+
+	ranking := markdown.NewTable(
+		markdown.Column{Header: "Rank", AlignRight: true},
+		markdown.Column{Header: "Candidate"},
+		markdown.Column{Header: "Improved", AlignRight: true},
+		markdown.Column{Header: "Regressed", AlignRight: true},
+		markdown.Column{Header: "Net", AlignRight: true},
+		markdown.Column{Header: "Newly solved", AlignRight: true},
+		markdown.Column{Header: "Newly unsolved", AlignRight: true},
+		markdown.Column{Header: "Time speedup", AlignRight: true},
+		markdown.Column{Header: "PAR-2 ratio", AlignRight: true},
+	)
+	for _, entry := range report.Ranking {
+		netMark := markdown.MarkUnchanged
+		if entry.Net > 0 {
+			netMark = markdown.MarkImprovement
+		} else if entry.Net < 0 {
+			netMark = markdown.MarkRegression
+		}
+		ranking.AddRow(
+			fmt.Sprintf("%d", entry.Rank),
+			markdown.Code(entry.Name),
+			fmt.Sprintf("%d", entry.Improvements),
+			fmt.Sprintf("%d", entry.Regressions),
+			fmt.Sprintf("%s %+d", netMark, entry.Net),
+			fmt.Sprintf("%d", entry.NewlySolved),
+			fmt.Sprintf("%d", entry.NewlyFailed),
+			fmt.Sprintf("%.3f×", entry.TimeSpeedup),
+			fmt.Sprintf("%s %.3f", ratioMark(entry.PAR2Ratio), entry.PAR2Ratio),
+		)
+	}
+	if err := ranking.Write(w); err != nil {
+		return err
+	}
+
+	return markdown.Details(w, fmt.Sprintf("Per-level time deltas (%d levels)", len(report.Levels)), func(w io.Writer) error {
+		columns := []markdown.Column{{Header: "Level"}}
+		for _, name := range report.CandidateNames {
+			columns = append(columns, markdown.Column{Header: markdown.Code(name), AlignRight: true})
+		}
+		levels := markdown.NewTable(columns...)
+		for _, level := range report.Levels {
+			row := []string{markdown.Escape(level.LevelName)}
+			for _, candidate := range level.Candidates {
+				text := formatDelta(candidate.Time)
+				if candidate.Solved.Status == StatusImprovement || candidate.Solved.Status == StatusRegression {
+					text = formatSolved(candidate.Solved)
+				}
+				row = append(row, statusMark(candidate.Time.Status)+" "+text)
+			}
+			levels.AddRow(row...)
+		}
+		return levels.Write(w)
+	})
+}
+
+func markdownCell(metric MetricComparison, format string) string {
+	cell := statusMark(metric.Status) + " " + formatPair(metric, format)
+	if !metric.Missing1 && !metric.Missing2 {
+		cell += fmt.Sprintf(" (%+.1f%%)", metric.DiffPct)
+	}
+	return cell
+}
+
+func statusMark(status string) string {
+	switch status {
+	case StatusImprovement:
+		return markdown.MarkImprovement
+	case StatusRegression:
+		return markdown.MarkRegression
+	case StatusMissing:
+		return markdown.MarkMissing
+	default:
+		return markdown.MarkUnchanged
+	}
+}
+
+func labelMark(label string) string {
+	switch label {
+	case SignificantImprovement:
+		return markdown.MarkImprovement
+	case SignificantRegression:
+		return markdown.MarkRegression
+	default:
+		return markdown.MarkUnchanged
+	}
+}
+
+// ratioMark marks a benchmark1 / benchmark2 ratio, below 1 is better
+func ratioMark(ratio float64) string {
+	switch {
+	case ratio > 0 && ratio < 1:
+		return markdown.MarkImprovement
+	case ratio > 1:
+		return markdown.MarkRegression
+	default:
+		return markdown.MarkUnchanged
+	}
+}
@@ -1,38 +1,169 @@
 package comparator
 
+import "masbench/internals/models"
+
+// Status values shared by metric and solved comparisons
+const (
+	StatusImprovement = "improvement"
+	StatusRegression  = "regression"
+	StatusUnchanged   = "unchanged"
+	StatusMissing     = "missing"
+)
+
+// Presence values describing in which benchmark a level was found
+const (
+	PresenceBoth  = "both"
+	PresenceOnly1 = "only1"
+	PresenceOnly2 = "only2"
+	PresenceNone  = "none"
+)
+
+// Significance labels for metrics compared over repeated runs
+const (
+	SignificantImprovement = "significant improvement"
+	SignificantRegression  = "significant regression"
+	Inconclusive           = "inconclusive"
+)
+
+// Options controls how metric differences are classified
+type Options struct {
+	// Tolerances keyed by metric column name, changes within them are unchanged
+	Tolerances map[string]models.Tolerance
+	// SignificanceLevel is the p-value under which a difference between
+	// repeated runs is considered significant
+	SignificanceLevel float64
+	// Timeout in seconds, unsolved levels cost twice this in PAR-2
+	Timeout int
+}
+
 type ComparisonReport struct {
-	Title          string
-	Benchmark1Name string
-	Benchmark2Name string
-	GeneratedAt    string
-	Levels         []LevelComparison
-	MetricNames    []string
+	Title            string               `json:"title"`
+	Benchmark1Name   string               `json:"benchmark1Name"`
+	Benchmark2Name   string               `json:"benchmark2Name"`
+	GeneratedAt      string               `json:"generatedAt"`
+	Levels           []LevelComparison    `json:"levels"`
+	MetricNames      []string             `json:"metricNames"`
+	OnlyInBenchmark1 []string             `json:"onlyInBenchmark1"`
+	OnlyInBenchmark2 []string             `json:"onlyInBenchmark2"`
+	HasRepeatedRuns  bool                 `json:"hasRepeatedRuns"`
+	Significance     []MetricSignificance `json:"significance"`
+	Aggregates       Aggregates           `json:"aggregates"`
+}
+
+// Aggregates summarizes the comparison over all the levels at once
+type Aggregates struct {
+	SolvedBoth   int               `json:"solvedBoth"`   // levels solved by both benchmarks
+	CommonLevels int               `json:"commonLevels"` // levels present in both benchmarks
+	PAR2Value1   float64           `json:"par2Value1"`
+	PAR2Value2   float64           `json:"par2Value2"`
+	PAR2Ratio    float64           `json:"par2Ratio"` // PAR2Value1 / PAR2Value2, below 1 means benchmark1 is faster
+	Metrics      []AggregateMetric `json:"metrics"`
+}
+
+// AggregateMetric holds the ratios of one metric over the levels solved by
+// both benchmarks. Ratios are benchmark1 / benchmark2, so below 1 is better,
+// except GeoMeanSpeedup which is its inverse. Levels with a non-positive
+// value on either side are left out of the ratios
+type AggregateMetric struct {
+	Metric            string  `json:"metric"`
+	Levels            int     `json:"levels"`
+	RatioLevels       int     `json:"ratioLevels"`
+	GeoMeanRatio      float64 `json:"geoMeanRatio"`
+	GeoMeanSpeedup    float64 `json:"geoMeanSpeedup"`
+	MedianRatio       float64 `json:"medianRatio"`
+	TotalRatio        float64 `json:"totalRatio"`
+	ImprovedFraction  float64 `json:"improvedFraction"`
+	RegressedFraction float64 `json:"regressedFraction"`
+}
+
+// MetricSignificance is the outcome of a Wilcoxon signed-rank test of one
+// metric over all the levels solved by both benchmarks
+type MetricSignificance struct {
+	Metric    string  `json:"metric"`
+	Levels    int     `json:"levels"` // levels with a non-zero difference
+	Improved  int     `json:"improved"`
+	Regressed int     `json:"regressed"`
+	WPlus     float64 `json:"wPlus"`
+	PValue    float64 `json:"pValue"`
+	Label     string  `json:"label"`
 }
 
 type LevelComparison struct {
-	LevelName   string
-	Generated   MetricComparison
-	Explored    MetricComparison
-	MemoryAlloc MetricComparison
-	Time        MetricComparison
-	Actions     MetricComparison
-	Solved      SolvedComparison
+	LevelName   string           `json:"levelName"`
+	Presence    string           `json:"presence"` // "both", "only1", "only2", "none"
+	Generated   MetricComparison `json:"generated"`
+	Explored    MetricComparison `json:"explored"`
+	MemoryAlloc MetricComparison `json:"memoryAlloc"`
+	Time        MetricComparison `json:"time"`
+	Actions     MetricComparison `json:"actions"`
+	Solved      SolvedComparison `json:"solved"`
+}
+
+// Metrics returns pointers to every metric comparison of the level,
+// in the same order as ComparisonReport.MetricNames
+func (l *LevelComparison) Metrics() []*MetricComparison {
+	return []*MetricComparison{&l.Generated, &l.Explored, &l.MemoryAlloc, &l.Time, &l.Actions}
 }
 
 type MetricComparison struct {
-	Value1        float64
-	Value2        float64
-	Diff          float64
-	DiffPct       float64
-	Status        string // "improvement", "regression", "unchanged"
-	IsImprovement bool
+	Value1        float64 `json:"value1"`
+	Value2        float64 `json:"value2"`
+	Missing1      bool    `json:"missing1"`
+	Missing2      bool    `json:"missing2"`
+	Runs1         int     `json:"runs1"`
+	Runs2         int     `json:"runs2"`
+	PValue        float64 `json:"pValue"` // only set when both sides have repeated runs
+	CILow         float64 `json:"ciLow"`  // bootstrap confidence interval of the median difference
+	CIHigh        float64 `json:"ciHigh"`
+	Significance  string  `json:"significance"` // significance label, empty without repeated runs
+	Diff          float64 `json:"diff"`
+	DiffPct       float64 `json:"diffPct"`
+	Status        string  `json:"status"` // "improvement", "regression", "unchanged", "missing"
+	IsImprovement bool    `json:"isImprovement"`
 }
 
 type SolvedComparison struct {
-	Solved1 string
-	Solved2 string
-	Changed bool
-	Status  string // "improved", "regressed", "unchanged"
+	Solved1  string `json:"solved1"`
+	Solved2  string `json:"solved2"`
+	Missing1 bool   `json:"missing1"`
+	Missing2 bool   `json:"missing2"`
+	Changed  bool   `json:"changed"`
+	Status   string `json:"status"` // "improvement", "regression", "unchanged", "missing"
+}
+
+// MultiComparisonReport compares several candidates against one baseline.
+// Every candidate is compared with the same logic as a two-way comparison,
+// with the candidate as benchmark1 and the baseline as benchmark2
+type MultiComparisonReport struct {
+	Title          string                 `json:"title"`
+	BaselineName   string                 `json:"baselineName"`
+	CandidateNames []string               `json:"candidateNames"`
+	GeneratedAt    string                 `json:"generatedAt"`
+	MetricNames    []string               `json:"metricNames"`
+	Comparisons    []ComparisonReport     `json:"comparisons"`
+	Levels         []MultiLevelComparison `json:"levels"`
+	Ranking        []CandidateRanking     `json:"ranking"`
+}
+
+// MultiLevelComparison holds one level compared for every candidate,
+// Candidates is aligned with MultiComparisonReport.CandidateNames
+type MultiLevelComparison struct {
+	LevelName  string            `json:"levelName"`
+	Candidates []LevelComparison `json:"candidates"`
+}
+
+// CandidateRanking counts the metric and solved changes of a candidate
+// relative to the baseline over all the levels
+type CandidateRanking struct {
+	Rank         int     `json:"rank"`
+	Name         string  `json:"name"`
+	Improvements int     `json:"improvements"`
+	Regressions  int     `json:"regressions"`
+	Net          int     `json:"net"`
+	NewlySolved  int     `json:"newlySolved"`
+	NewlyFailed  int     `json:"newlyFailed"`
+	TimeSpeedup  float64 `json:"timeSpeedup"` // geometric mean time speedup over the baseline
+	PAR2Ratio    float64 `json:"par2Ratio"`
 }
 
 type ChartData struct {
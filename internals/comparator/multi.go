@@ -0,0 +1,173 @@
+package comparator
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"masbench/internals/assets"
+	"masbench/internals/models"
+	"sort"
+	"time"
+
+	"github.com/go-gota/gota/dataframe"
+)
+
+// metricCell is the data of a single metric cell in the multi report template
+type metricCell struct {
+	Name   string
+	Format string
+	Metric MetricComparison
+}
+
+// WriteMultiHTML renders the multi comparison report as an interactive HTML page
+func WriteMultiHTML(w io.Writer, report MultiComparisonReport) error {
+	funcMap := template.FuncMap{
+		"cell": func(name, format string, metric MetricComparison) metricCell {
+			return metricCell{Name: name, Format: format, Metric: metric}
+		},
+		"styles": assets.Styles,
+		"script": assets.ScriptTag,
+	}
+
+	tmpl, err := template.New("multi").Funcs(funcMap).Parse(multiReportTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	if err := tmpl.Execute(w, report); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return nil
+}
+
+// PrepareMultiComparisonData compares every candidate against the baseline
+func PrepareMultiComparisonData(baseline dataframe.DataFrame, baselineName string, candidates []dataframe.DataFrame, candidateNames []string, opts Options) MultiComparisonReport {
+	report := MultiComparisonReport{
+		Title:          "Multi-Benchmark Comparison Report",
+		BaselineName:   baselineName,
+		CandidateNames: candidateNames,
+		GeneratedAt:    time.Now().Format("2006-01-02 15:04:05"),
+	}
+
+	// Levels are listed in baseline order, followed by the levels that
+	// only some candidates have
+	levelOrder := []string{}
+	seen := make(map[string]bool)
+	byLevel := make([]map[string]LevelComparison, len(candidates))
+
+	for i, candidate := range candidates {
+		comparison := PrepareComparisonData(candidate, baseline, candidateNames[i], baselineName, opts)
+		report.Comparisons = append(report.Comparisons, comparison)
+		report.MetricNames = comparison.MetricNames
+
+		byLevel[i] = make(map[string]LevelComparison)
+		for _, level := range comparison.Levels {
+			byLevel[i][level.LevelName] = level
+		}
+	}
+
+	for _, level := range baseline.Col(models.ColLevelName).Records() {
+		if !seen[level] {
+			seen[level] = true
+			levelOrder = append(levelOrder, level)
+		}
+	}
+	for _, comparison := range report.Comparisons {
+		for _, level := range comparison.OnlyInBenchmark1 {
+			if !seen[level] {
+				seen[level] = true
+				levelOrder = append(levelOrder, level)
+			}
+		}
+	}
+
+	for _, levelName := range levelOrder {
+		multiLevel := MultiLevelComparison{LevelName: levelName}
+		for i := range candidates {
+			level, ok := byLevel[i][levelName]
+			if !ok {
+				level = absentLevel(levelName)
+			}
+			multiLevel.Candidates = append(multiLevel.Candidates, level)
+		}
+		report.Levels = append(report.Levels, multiLevel)
+	}
+
+	report.Ranking = rankCandidates(report.Comparisons)
+
+	return report
+}
+
+// absentLevel is the comparison of a level that neither the candidate nor
+// the baseline contain, it only exists in another candidate
+func absentLevel(levelName string) LevelComparison {
+	level := LevelComparison{
+		LevelName: levelName,
+		Presence:  PresenceNone,
+		Solved: SolvedComparison{
+			Missing1: true,
+			Missing2: true,
+			Status:   StatusMissing,
+		},
+	}
+	for _, metric := range level.Metrics() {
+		metric.Missing1 = true
+		metric.Missing2 = true
+		metric.Status = StatusMissing
+	}
+	return level
+}
+
+// rankCandidates orders the candidates by net improvements over the baseline,
+// breaking ties with fewer regressions and then by name
+func rankCandidates(comparisons []ComparisonReport) []CandidateRanking {
+	ranking := make([]CandidateRanking, 0, len(comparisons))
+
+	for _, comparison := range comparisons {
+		entry := CandidateRanking{
+			Name:      comparison.Benchmark1Name,
+			PAR2Ratio: comparison.Aggregates.PAR2Ratio,
+		}
+		for _, aggregate := range comparison.Aggregates.Metrics {
+			if aggregate.Metric == models.ColTime {
+				entry.TimeSpeedup = aggregate.GeoMeanSpeedup
+			}
+		}
+		for i := range comparison.Levels {
+			level := &comparison.Levels[i]
+			for _, metric := range level.Metrics() {
+				switch metric.Status {
+				case StatusImprovement:
+					entry.Improvements++
+				case StatusRegression:
+					entry.Regressions++
+				}
+			}
+			switch level.Solved.Status {
+			case StatusImprovement:
+				entry.NewlySolved++
+			case StatusRegression:
+				entry.NewlyFailed++
+			}
+		}
+		entry.Net = entry.Improvements - entry.Regressions
+		ranking = append(ranking, entry)
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		if ranking[i].Net != ranking[j].Net {
+			return ranking[i].Net > ranking[j].Net
+		}
+		if ranking[i].Regressions != ranking[j].Regressions {
+			return ranking[i].Regressions < ranking[j].Regressions
+		}
+		return ranking[i].Name < ranking[j].Name
+	})
+
+	for i := range ranking {
+		ranking[i].Rank = i + 1
+	}
+
+	return ranking
+}
@@ -0,0 +1,225 @@
+package comparator
+
+const multiReportTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}}</title>
+    {{styles}}
+    <style>
+        body {
+            color: #000000;
+            background-color: #f9fafb;
+        }
+        .dark {
+            background-color: #111827 !important;
+            color: #ffffff !important;
+        }
+        .dark * {
+            color: #ffffff;
+        }
+        .dark .text-gray-500 {
+            color: #9ca3af !important;
+        }
+        .dark .bg-white {
+            background-color: #1f2937 !important;
+        }
+        .dark .bg-gray-50 {
+            background-color: #111827 !important;
+        }
+        .dark .border-gray-200 {
+            border-color: #4b5563 !important;
+        }
+        .dark thead {
+            background-color: #1f2937 !important;
+        }
+        .improvement {
+            background-color: #dcfce7;
+            color: #000000;
+        }
+        .regression {
+            background-color: #fee2e2;
+            color: #000000;
+        }
+        .unchanged {
+            background-color: #f3f4f6;
+            color: #000000;
+        }
+        .missing {
+            background-color: #fef9c3;
+            color: #000000;
+        }
+        .dark .improvement {
+            background-color: #064e3b;
+        }
+        .dark .regression {
+            background-color: #7f1d1d;
+        }
+        .dark .unchanged {
+            background-color: #374151;
+        }
+        .dark .missing {
+            background-color: #713f12;
+        }
+        .dark tbody {
+            background-color: #1f2937 !important;
+        }
+        .dark .divide-gray-200 > * {
+            border-color: #4b5563 !important;
+        }
+    </style>
+</head>
+<body class="bg-gray-50 transition-colors duration-200">
+    <!-- Header -->
+    <div class="bg-white shadow-sm border-b border-gray-200">
+        <div class="max-w-7xl mx-auto px-4 py-6">
+            <div class="flex justify-between items-center">
+                <div>
+                    <h1 class="text-3xl font-bold text-gray-900">📊 Multi-Benchmark Comparison Report</h1>
+                    <div class="mt-2 flex items-center gap-2 text-sm text-gray-600 flex-wrap">
+                        <span>Baseline:</span>
+                        <span class="font-semibold text-orange-600">{{.BaselineName}}</span>
+                        <span class="text-gray-400">•</span>
+                        <span>Candidates:</span>
+                        {{range $i, $name := .CandidateNames}}{{if $i}}<span class="text-gray-400">,</span>{{end}}<span class="font-semibold text-blue-600">{{$name}}</span>{{end}}
+                        <span class="text-gray-400">•</span>
+                        <span>{{.GeneratedAt}}</span>
+                    </div>
+                </div>
+                <button onclick="toggleDarkMode()" class="px-4 py-2 bg-gray-800 text-white rounded-lg hover:bg-gray-700 transition">
+                    🌙 Dark Mode
+                </button>
+            </div>
+        </div>
+    </div>
+
+    <!-- Info Banner -->
+    <div class="max-w-7xl mx-auto px-4 py-6">
+        <div class="bg-blue-50 border-l-4 border-blue-500 p-4 rounded-lg">
+            <p class="text-sm text-blue-700">
+                Every candidate is compared against <strong>{{.BaselineName}}</strong>.
+                Green indicates the candidate is better than the baseline, Red indicates worse.
+                Improvements and regressions are counted per level and metric.
+            </p>
+        </div>
+    </div>
+
+    <!-- Ranking -->
+    <div class="max-w-7xl mx-auto px-4 py-4">
+        <div class="bg-white rounded-lg shadow border border-gray-200">
+            <div class="p-6 border-b border-gray-200">
+                <h2 class="text-2xl font-bold text-gray-900">🏆 Candidate Ranking</h2>
+            </div>
+            <div class="overflow-x-auto">
+                <table class="min-w-full divide-y divide-gray-200">
+                    <thead class="bg-gray-50">
+                        <tr>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Rank</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Candidate</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Improvements</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Regressions</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Net</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Newly Solved</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Newly Unsolved</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Time Speedup</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">PAR-2 Ratio</th>
+                        </tr>
+                    </thead>
+                    <tbody class="bg-white divide-y divide-gray-200">
+                        {{range .Ranking}}
+                        <tr>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm font-bold text-gray-900">#{{.Rank}}</td>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm font-semibold text-blue-600">{{.Name}}</td>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm text-green-600">{{.Improvements}}</td>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm text-red-600">{{.Regressions}}</td>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm font-semibold {{if gt .Net 0}}improvement{{else if lt .Net 0}}regression{{else}}unchanged{{end}}">{{.Net}}</td>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.NewlySolved}}</td>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.NewlyFailed}}</td>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{printf "%.3f×" .TimeSpeedup}}</td>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{printf "%.3f" .PAR2Ratio}}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+        </div>
+    </div>
+
+    <!-- Side by Side Comparison -->
+    <div class="max-w-7xl mx-auto px-4 py-8">
+        <div class="bg-white rounded-lg shadow border border-gray-200">
+            <div class="p-6 border-b border-gray-200">
+                <div class="flex justify-between items-center">
+                    <h2 class="text-2xl font-bold text-gray-900">Per-Level Deltas</h2>
+                    <select id="metricSelect" class="px-4 py-2 border border-gray-300 rounded-lg focus:outline-none focus:ring-2 focus:ring-blue-500" onchange="showMetric(this.value)">
+                        <option value="Time">Time</option>
+                        <option value="Actions">Actions</option>
+                        <option value="Generated">Generated</option>
+                        <option value="Explored">Explored</option>
+                        <option value="MemoryAlloc">Memory</option>
+                        <option value="Solved">Solved</option>
+                    </select>
+                </div>
+            </div>
+            <div class="overflow-x-auto">
+                <table class="min-w-full divide-y divide-gray-200">
+                    <thead class="bg-gray-50">
+                        <tr>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Level Name</th>
+                            {{range .CandidateNames}}
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">{{.}} vs {{$.BaselineName}}</th>
+                            {{end}}
+                        </tr>
+                    </thead>
+                    <tbody class="bg-white divide-y divide-gray-200">
+                        {{range .Levels}}
+                        <tr>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm font-medium text-gray-900">{{.LevelName}}</td>
+                            {{range .Candidates}}
+                            <td class="px-0 py-0 whitespace-nowrap text-sm text-gray-900">
+                                {{template "cell" (cell "Generated" "%.0f" .Generated)}}
+                                {{template "cell" (cell "Explored" "%.0f" .Explored)}}
+                                {{template "cell" (cell "MemoryAlloc" "%.2f" .MemoryAlloc)}}
+                                {{template "cell" (cell "Time" "%.3f" .Time)}}
+                                {{template "cell" (cell "Actions" "%.0f" .Actions)}}
+                                <div class="metric-cell px-6 py-4 {{.Solved.Status}}" data-metric="Solved">
+                                    <div class="font-semibold">{{if .Solved.Missing1}}n/a{{else}}{{.Solved.Solved1}}{{end}} vs {{if .Solved.Missing2}}n/a{{else}}{{.Solved.Solved2}}{{end}}</div>
+                                </div>
+                            </td>
+                            {{end}}
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+        </div>
+    </div>
+
+    <!-- Footer -->
+    <div class="max-w-7xl mx-auto px-4 py-8 text-center text-sm text-gray-500">
+        <p>Generated by masbench compare command</p>
+        <p class="mt-1">Tailwind CSS v3.4.0</p>
+    </div>
+
+    <script>
+        // Show only the cells of the selected metric
+        function showMetric(metric) {
+            document.querySelectorAll('.metric-cell').forEach(el => {
+                el.style.display = el.getAttribute('data-metric') === metric ? '' : 'none';
+            });
+        }
+
+        // Dark mode toggle
+        function toggleDarkMode() {
+            document.body.classList.toggle('dark');
+        }
+
+        showMetric('Time');
+    </script>
+</body>
+</html>
+{{define "cell"}}<div class="metric-cell px-6 py-4 {{.Metric.Status}}" data-metric="{{.Name}}">
+                                    <div class="font-semibold">{{if .Metric.Missing1}}n/a{{else}}{{printf .Format .Metric.Value1}}{{end}} vs {{if .Metric.Missing2}}n/a{{else}}{{printf .Format .Metric.Value2}}{{end}}</div>
+                                    <div class="text-xs">{{if or .Metric.Missing1 .Metric.Missing2}}missing{{else}}{{printf "%+.1f%%" .Metric.DiffPct}}{{end}}</div>
+                                </div>{{end}}`
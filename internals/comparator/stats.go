@@ -0,0 +1,253 @@
+package comparator
+
+import (
+	"masbench/internals/utils"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// exactTestLimit is the largest sample size for which the exact
+// Mann-Whitney distribution is computed instead of the normal approximation
+const exactTestLimit = 20
+
+// mannWhitneyU performs a two-sided Mann-Whitney U test and returns the
+// U statistic of a together with the p-value. Small samples without ties
+// use the exact distribution, the others the tie-corrected normal approximation
+func mannWhitneyU(a, b []float64) (float64, float64) {
+	n1, n2 := len(a), len(b)
+	if n1 == 0 || n2 == 0 {
+		return 0, 1
+	}
+
+	ranks, tieGroups := rankAll(a, b)
+
+	rankSum := 0.0
+	for i := 0; i < n1; i++ {
+		rankSum += ranks[i]
+	}
+	u := rankSum - float64(n1*(n1+1))/2
+
+	if len(tieGroups) == 0 && n1 <= exactTestLimit && n2 <= exactTestLimit {
+		return u, exactMannWhitneyP(n1, n2, u)
+	}
+
+	n := float64(n1 + n2)
+	tieSum := 0.0
+	for _, t := range tieGroups {
+		tieSum += float64(t*t*t - t)
+	}
+
+	mu := float64(n1*n2) / 2
+	variance := float64(n1*n2) / 12 * ((n + 1) - tieSum/(n*(n-1)))
+	if variance <= 0 {
+		return u, 1
+	}
+
+	z := (math.Abs(u-mu) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		z = 0
+	}
+	return u, math.Min(1, math.Erfc(z/math.Sqrt2))
+}
+
+// rankAll ranks the concatenation of a and b, giving tied values their
+// average rank. It also returns the size of every group of ties
+func rankAll(a, b []float64) ([]float64, []int) {
+	type item struct {
+		value float64
+		index int
+	}
+
+	items := make([]item, 0, len(a)+len(b))
+	for i, v := range a {
+		items = append(items, item{v, i})
+	}
+	for i, v := range b {
+		items = append(items, item{v, len(a) + i})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].value < items[j].value })
+
+	ranks := make([]float64, len(items))
+	var tieGroups []int
+	for i := 0; i < len(items); {
+		j := i
+		for j < len(items) && items[j].value == items[i].value {
+			j++
+		}
+		avgRank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			ranks[items[k].index] = avgRank
+		}
+		if j-i > 1 {
+			tieGroups = append(tieGroups, j-i)
+		}
+		i = j
+	}
+
+	return ranks, tieGroups
+}
+
+// exactMannWhitneyP computes the two-sided p-value of u using the exact
+// distribution of the U statistic for samples of size n1 and n2
+func exactMannWhitneyP(n1, n2 int, u float64) float64 {
+	// counts[i][j][k] is the number of orderings of i and j elements with U = k
+	counts := make([][][]float64, n1+1)
+	for i := 0; i <= n1; i++ {
+		counts[i] = make([][]float64, n2+1)
+		for j := 0; j <= n2; j++ {
+			counts[i][j] = make([]float64, i*j+1)
+			if i == 0 || j == 0 {
+				counts[i][j][0] = 1
+				continue
+			}
+			for k := 0; k <= i*j; k++ {
+				if k-j >= 0 && k-j < len(counts[i-1][j]) {
+					counts[i][j][k] += counts[i-1][j][k-j]
+				}
+				if k < len(counts[i][j-1]) {
+					counts[i][j][k] += counts[i][j-1][k]
+				}
+			}
+		}
+	}
+
+	dist := counts[n1][n2]
+	total := 0.0
+	for _, c := range dist {
+		total += c
+	}
+
+	target := int(math.Round(u))
+	lower, upper := 0.0, 0.0
+	for k, c := range dist {
+		if k <= target {
+			lower += c
+		}
+		if k >= target {
+			upper += c
+		}
+	}
+
+	return math.Min(1, 2*math.Min(lower, upper)/total)
+}
+
+// bootstrapIterations is the number of resamples used for confidence intervals
+const bootstrapIterations = 2000
+
+// bootstrapMedianDiffCI returns the bootstrap percentile confidence interval
+// of median(a) - median(b) at the given confidence (e.g. 0.95). A fixed seed
+// keeps reports reproducible
+func bootstrapMedianDiffCI(a, b []float64, confidence float64) (float64, float64) {
+	if len(a) == 0 || len(b) == 0 {
+		return 0, 0
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	diffs := make([]float64, bootstrapIterations)
+	resampleA := make([]float64, len(a))
+	resampleB := make([]float64, len(b))
+
+	for i := range diffs {
+		for j := range resampleA {
+			resampleA[j] = a[rng.Intn(len(a))]
+		}
+		for j := range resampleB {
+			resampleB[j] = b[rng.Intn(len(b))]
+		}
+		diffs[i] = utils.Median(resampleA) - utils.Median(resampleB)
+	}
+
+	sort.Float64s(diffs)
+	tail := (1 - confidence) / 2
+	return percentile(diffs, tail), percentile(diffs, 1-tail)
+}
+
+// percentile returns the p-th quantile (0..1) of an already sorted slice
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(math.Round(p * float64(len(sorted)-1)))
+	return sorted[idx]
+}
+
+// wilcoxonSignedRank performs a two-sided Wilcoxon signed-rank test on the
+// paired differences, ignoring zero differences. It returns the sum of the
+// ranks of the positive differences, the number of non-zero pairs and the p-value
+func wilcoxonSignedRank(diffs []float64) (float64, int, float64) {
+	var nonZero []float64
+	for _, d := range diffs {
+		if d != 0 {
+			nonZero = append(nonZero, d)
+		}
+	}
+
+	n := len(nonZero)
+	if n == 0 {
+		return 0, 0, 1
+	}
+
+	abs := make([]float64, n)
+	for i, d := range nonZero {
+		abs[i] = math.Abs(d)
+	}
+	ranks, tieGroups := rankAll(abs, nil)
+
+	wPlus := 0.0
+	for i, d := range nonZero {
+		if d > 0 {
+			wPlus += ranks[i]
+		}
+	}
+
+	if len(tieGroups) == 0 && n <= exactTestLimit {
+		return wPlus, n, exactWilcoxonP(n, wPlus)
+	}
+
+	tieSum := 0.0
+	for _, t := range tieGroups {
+		tieSum += float64(t*t*t - t)
+	}
+
+	nf := float64(n)
+	mu := nf * (nf + 1) / 4
+	variance := nf*(nf+1)*(2*nf+1)/24 - tieSum/48
+	if variance <= 0 {
+		return wPlus, n, 1
+	}
+
+	z := (math.Abs(wPlus-mu) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		z = 0
+	}
+	return wPlus, n, math.Min(1, math.Erfc(z/math.Sqrt2))
+}
+
+// exactWilcoxonP computes the two-sided p-value of w using the exact
+// distribution of the signed-rank statistic for n pairs
+func exactWilcoxonP(n int, w float64) float64 {
+	maxSum := n * (n + 1) / 2
+	// counts[s] is the number of subsets of ranks 1..k summing to s
+	counts := make([]float64, maxSum+1)
+	counts[0] = 1
+	for k := 1; k <= n; k++ {
+		for s := maxSum; s >= k; s-- {
+			counts[s] += counts[s-k]
+		}
+	}
+
+	total := math.Pow(2, float64(n))
+	target := int(math.Round(w))
+	lower, upper := 0.0, 0.0
+	for s, c := range counts {
+		if s <= target {
+			lower += c
+		}
+		if s >= target {
+			upper += c
+		}
+	}
+
+	return math.Min(1, 2*math.Min(lower, upper)/total)
+}
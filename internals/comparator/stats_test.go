@@ -0,0 +1,90 @@
+package comparator
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+const pTolerance = 1e-4
+
+func TestMannWhitneyU(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		u, p float64
+	}{
+		{"exact, a below b", []float64{1, 2, 3}, []float64{4, 5, 6}, 0, 0.1},
+		{"exact, a above b", []float64{4, 5, 6}, []float64{1, 2, 3}, 9, 0.1},
+		{"exact, interleaved", []float64{1, 3, 5}, []float64{2, 4, 6}, 3, 0.7},
+		{"exact, five per side", []float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 0, 2.0 / 252},
+		{"normal approximation with ties", []float64{1, 2, 2}, []float64{2, 3, 3}, 1, 0.157299},
+		{"empty sample", nil, []float64{1, 2}, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, p := mannWhitneyU(tt.a, tt.b)
+			if u != tt.u || math.Abs(p-tt.p) > pTolerance {
+				t.Errorf("mannWhitneyU(%v, %v) = (%g, %g), want (%g, %g)", tt.a, tt.b, u, p, tt.u, tt.p)
+			}
+		})
+	}
+}
+
+func TestWilcoxonSignedRank(t *testing.T) {
+	tests := []struct {
+		name  string
+		diffs []float64
+		w     float64
+		n     int
+		p     float64
+	}{
+		{"exact, all positive", []float64{1, 2, 3, 4, 5}, 15, 5, 0.0625},
+		{"exact, all negative", []float64{-1, -2, -3, -4, -5}, 0, 5, 0.0625},
+		{"exact, zero dropped", []float64{1, -2, 3, 0}, 4, 3, 0.75},
+		{"normal approximation with ties", []float64{1, 1, -2, 3}, 7, 4, 0.580712},
+		{"only zeros", []float64{0, 0}, 0, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, n, p := wilcoxonSignedRank(tt.diffs)
+			if w != tt.w || n != tt.n || math.Abs(p-tt.p) > pTolerance {
+				t.Errorf("wilcoxonSignedRank(%v) = (%g, %d, %g), want (%g, %d, %g)", tt.diffs, w, n, p, tt.w, tt.n, tt.p)
+			}
+		})
+	}
+}
+
+func TestRankAll(t *testing.T) {
+	ranks, ties := rankAll([]float64{3, 1}, []float64{3, 2})
+	if want := []float64{3.5, 1, 3.5, 2}; !slices.Equal(ranks, want) {
+		t.Errorf("ranks = %v, want %v", ranks, want)
+	}
+	if want := []int{2}; !slices.Equal(ties, want) {
+		t.Errorf("tie groups = %v, want %v", ties, want)
+	}
+}
+
+func TestBootstrapMedianDiffCI(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   []float64
+		lo, hi float64 // bounds the interval must stay within
+	}{
+		{"constant shift", []float64{12, 12, 12}, []float64{2, 2, 2}, 10, 10},
+		{"identical samples", []float64{5, 5}, []float64{5, 5}, 0, 0},
+		{"noisy shift", []float64{10, 11, 12, 13, 14}, []float64{0, 1, 2, 3, 4}, 6, 14},
+		{"empty sample", nil, []float64{1}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi := bootstrapMedianDiffCI(tt.a, tt.b, 0.95)
+			if lo > hi || lo < tt.lo || hi > tt.hi {
+				t.Errorf("bootstrapMedianDiffCI(%v, %v) = [%g, %g], want within [%g, %g]", tt.a, tt.b, lo, hi, tt.lo, tt.hi)
+			}
+			if lo2, hi2 := bootstrapMedianDiffCI(tt.a, tt.b, 0.95); lo2 != lo || hi2 != hi {
+				t.Errorf("bootstrapMedianDiffCI is not reproducible: [%g, %g] then [%g, %g]", lo, hi, lo2, hi2)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package comparator
+
+import (
+	"fmt"
+	"io"
+	"masbench/internals/terminal"
+	"math"
+	"strings"
+)
+
+// deltaBarWidth is the width of the per-level delta bars
+const deltaBarWidth = 21
+
+// deltaBarMax is the percentage change drawn as a full bar
+const deltaBarMax = 100.0
+
+// WriteTable renders the comparison report as terminal tables fitting width
+func WriteTable(w io.Writer, report ComparisonReport, width int, color bool) error {
+	fmt.Fprintf(w, "%s\n", terminal.Paint(report.Title, terminal.ColorBold, color))
+	fmt.Fprintf(w, "%s vs %s • %s\n\n",
+		terminal.Paint(report.Benchmark1Name, terminal.ColorBlue, color),
+		terminal.Paint(report.Benchmark2Name, terminal.ColorYellow, color),
+		report.GeneratedAt)
+
+	agg := report.Aggregates
+	fmt.Fprintf(w, "Solved by both: %d / %d common levels\n", agg.SolvedBoth, agg.CommonLevels)
+	fmt.Fprintf(w, "PAR-2: %.2fs vs %.2fs (ratio %s)\n\n", agg.PAR2Value1, agg.PAR2Value2,
+		terminal.Paint(fmt.Sprintf("%.3f", agg.PAR2Ratio), ratioColor(agg.PAR2Ratio), color))
+
+	headline := terminal.NewTable(
+		terminal.Column{Header: "Metric"},
+		terminal.Column{Header: "Levels", AlignRight: true},
+		terminal.Column{Header: "Speedup", AlignRight: true},
+		terminal.Column{Header: "Median", AlignRight: true},
+		terminal.Column{Header: "Total", AlignRight: true},
+		terminal.Column{Header: "Improved", AlignRight: true},
+		terminal.Column{Header: "Regressed", AlignRight: true},
+		terminal.Column{Header: "Deltas", Optional: true},
+	)
+	for i, aggregate := range agg.Metrics {
+		speedup, medianRatio, total := "n/a", "n/a", "n/a"
+		if aggregate.RatioLevels > 0 {
+			speedup = fmt.Sprintf("%.3f×", aggregate.GeoMeanSpeedup)
+			medianRatio = fmt.Sprintf("%.3f", aggregate.MedianRatio)
+		}
+		if aggregate.TotalRatio > 0 {
+			total = fmt.Sprintf("%.3f", aggregate.TotalRatio)
+		}
+		headline.AddRow(
+			terminal.Text(aggregate.Metric),
+			terminal.Text(fmt.Sprintf("%d", aggregate.Levels)),
+			terminal.Colored(speedup, ratioColor(aggregate.GeoMeanRatio)),
+			terminal.Text(medianRatio),
+			terminal.Text(total),
+			terminal.Colored(fmt.Sprintf("%.1f%%", aggregate.ImprovedFraction*100), terminal.ColorGreen),
+			terminal.Colored(fmt.Sprintf("%.1f%%", aggregate.RegressedFraction*100), terminal.ColorRed),
+			terminal.Text(terminal.Sparkline(deltaSeries(report.Levels, i))),
+		)
+	}
+	if err := headline.Render(w, width, color); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+
+	levels := terminal.NewTable(
+		terminal.Column{Header: "Level"},
+		terminal.Column{Header: "Solved"},
+		terminal.Column{Header: "Time", AlignRight: true},
+		terminal.Column{Header: "ΔTime", AlignRight: true},
+		terminal.Column{Header: "", Optional: true},
+		terminal.Column{Header: "Actions", AlignRight: true},
+		terminal.Column{Header: "ΔActions", AlignRight: true},
+		terminal.Column{Header: "ΔGenerated", AlignRight: true, Optional: true},
+		terminal.Column{Header: "ΔExplored", AlignRight: true, Optional: true},
+		terminal.Column{Header: "ΔMemory", AlignRight: true, Optional: true},
+	)
+	for _, level := range report.Levels {
+		levels.AddRow(
+			terminal.Text(level.LevelName),
+			terminal.Colored(formatSolved(level.Solved), statusColor(level.Solved.Status)),
+			terminal.Text(formatPair(level.Time, "%.3f")),
+			terminal.Colored(formatDelta(level.Time), statusColor(level.Time.Status)),
+			terminal.Colored(deltaBar(level.Time), statusColor(level.Time.Status)),
+			terminal.Text(formatPair(level.Actions, "%.0f")),
+			terminal.Colored(formatDelta(level.Actions), statusColor(level.Actions.Status)),
+			terminal.Colored(formatDelta(level.Generated), statusColor(level.Generated.Status)),
+			terminal.Colored(formatDelta(level.Explored), statusColor(level.Explored.Status)),
+			terminal.Colored(formatDelta(level.MemoryAlloc), statusColor(level.MemoryAlloc.Status)),
+		)
+	}
+	if err := levels.Render(w, width, color); err != nil {
+		return err
+	}
+
+	if len(report.OnlyInBenchmark1) > 0 || len(report.OnlyInBenchmark2) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Only in %s: %s\n", report.Benchmark1Name, listOrNone(report.OnlyInBenchmark1))
+		fmt.Fprintf(w, "Only in %s: %s\n", report.Benchmark2Name, listOrNone(report.OnlyInBenchmark2))
+	}
+
+	fmt.Fprintln(w)
+	significance := terminal.NewTable(
+		terminal.Column{Header: "Metric"},
+		terminal.Column{Header: "Levels", AlignRight: true},
+		terminal.Column{Header: "p-value", AlignRight: true},
+		terminal.Column{Header: "Result"},
+	)
+	for _, result := range report.Significance {
+		significance.AddRow(
+			terminal.Text(result.Metric),
+			terminal.Text(fmt.Sprintf("%d", result.Levels)),
+			terminal.Text(fmt.Sprintf("%.4f", result.PValue)),
+			terminal.Colored(result.Label, labelColor(result.Label)),
+		)
+	}
+	return significance.Render(w, width, color)
+}
+
+// WriteMultiTable renders the multi comparison report as terminal tables
+func WriteMultiTable(w io.Writer, report MultiComparisonReport, width int, color bool) error {
+	fmt.Fprintf(w, "%s\n", terminal.Paint(report.Title, terminal.ColorBold, color))
+	fmt.Fprintf(w, "Baseline %s • %s\n\n", terminal.Paint(report.BaselineName, terminal.ColorYellow, color), report.GeneratedAt)
+
+	ranking := terminal.NewTable(
+		terminal.Column{Header: "Rank", AlignRight: true},
+		terminal.Column{Header: "Candidate"},
+		terminal.Column{Header: "Improved", AlignRight: true},
+		terminal.Column{Header: "Regressed", AlignRight: true},
+		terminal.Column{Header: "Net", AlignRight: true},
+		terminal.Column{Header: "Newly solved", AlignRight: true},
+		terminal.Column{Header: "Newly unsolved", AlignRight: true},
+		terminal.Column{Header: "Speedup", AlignRight: true},
+		terminal.Column{Header: "PAR-2 ratio", AlignRight: true},
+	)
+	for _, entry := range report.Ranking {
+		netColor := terminal.ColorGray
+		if entry.Net > 0 {
+			netColor = terminal.ColorGreen
+		} else if entry.Net < 0 {
+			netColor = terminal.ColorRed
+		}
+		ranking.AddRow(
+			terminal.Text(fmt.Sprintf("#%d", entry.Rank)),
+			terminal.Colored(entry.Name, terminal.ColorBlue),
+			terminal.Text(fmt.Sprintf("%d", entry.Improvements)),
+			terminal.Text(fmt.Sprintf("%d", entry.Regressions)),
+			terminal.Colored(fmt.Sprintf("%+d", entry.Net), netColor),
+			terminal.Text(fmt.Sprintf("%d", entry.NewlySolved)),
+			terminal.Text(fmt.Sprintf("%d", entry.NewlyFailed)),
+			terminal.Text(fmt.Sprintf("%.3f×", entry.TimeSpeedup)),
+			terminal.Colored(fmt.Sprintf("%.3f", entry.PAR2Ratio), ratioColor(entry.PAR2Ratio)),
+		)
+	}
+	if err := ranking.Render(w, width, color); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+
+	columns := []terminal.Column{{Header: "Level"}}
+	for _, name := range report.CandidateNames {
+		columns = append(columns, terminal.Column{Header: name + " ΔTime", AlignRight: true})
+	}
+	levels := terminal.NewTable(columns...)
+	for _, level := range report.Levels {
+		row := []terminal.Cell{terminal.Text(level.LevelName)}
+		for _, candidate := range level.Candidates {
+			text := formatDelta(candidate.Time)
+			if candidate.Solved.Status == StatusImprovement || candidate.Solved.Status == StatusRegression {
+				text = formatSolved(candidate.Solved)
+			}
+			row = append(row, terminal.Colored(text, statusColor(candidate.Time.Status)))
+		}
+		levels.AddRow(row...)
+	}
+	return levels.Render(w, width, color)
+}
+
+// deltaSeries returns the percentage change of the metric at index m of
+// every level, NaN where the metric is missing
+func deltaSeries(levels []LevelComparison, m int) []float64 {
+	series := make([]float64, 0, len(levels))
+	for i := range levels {
+		metric := levels[i].Metrics()[m]
+		if metric.Missing1 || metric.Missing2 {
+			series = append(series, math.NaN())
+			continue
+		}
+		series = append(series, metric.DiffPct)
+	}
+	return series
+}
+
+func deltaBar(metric MetricComparison) string {
+	if metric.Missing1 || metric.Missing2 {
+		return terminal.DeltaBar(math.NaN(), deltaBarMax, deltaBarWidth)
+	}
+	return terminal.DeltaBar(metric.DiffPct, deltaBarMax, deltaBarWidth)
+}
+
+func formatPair(metric MetricComparison, format string) string {
+	value1, value2 := "n/a", "n/a"
+	if !metric.Missing1 {
+		value1 = fmt.Sprintf(format, metric.Value1)
+	}
+	if !metric.Missing2 {
+		value2 = fmt.Sprintf(format, metric.Value2)
+	}
+	return value1 + " vs " + value2
+}
+
+func formatDelta(metric MetricComparison) string {
+	if metric.Missing1 || metric.Missing2 {
+		return "missing"
+	}
+	return fmt.Sprintf("%+.1f%%", metric.DiffPct)
+}
+
+func formatSolved(solved SolvedComparison) string {
+	solved1, solved2 := solved.Solved1, solved.Solved2
+	if solved.Missing1 {
+		solved1 = "n/a"
+	}
+	if solved.Missing2 {
+		solved2 = "n/a"
+	}
+	return solved1 + " vs " + solved2
+}
+
+func listOrNone(levels []string) string {
+	if len(levels) == 0 {
+		return "none"
+	}
+	return strings.Join(levels, ", ")
+}
+
+func statusColor(status string) string {
+	switch status {
+	case StatusImprovement:
+		return terminal.ColorGreen
+	case StatusRegression:
+		return terminal.ColorRed
+	case StatusMissing:
+		return terminal.ColorYellow
+	default:
+		return terminal.ColorGray
+	}
+}
+
+func labelColor(label string) string {
+	switch label {
+	case SignificantImprovement:
+		return terminal.ColorGreen
+	case SignificantRegression:
+		return terminal.ColorRed
+	default:
+		return terminal.ColorGray
+	}
+}
+
+// ratioColor colors a benchmark1 / benchmark2 ratio, below 1 is better
+func ratioColor(ratio float64) string {
+	switch {
+	case ratio == 0:
+		return terminal.ColorGray
+	case ratio < 1:
+		return terminal.ColorGreen
+	case ratio > 1:
+		return terminal.ColorRed
+	default:
+		return terminal.ColorGray
+	}
+}
@@ -10,9 +10,9 @@ const reportTemplate = `<!DOCTYPE html>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
     <title>{{.Title}}</title>
-    <script src="https://cdn.tailwindcss.com"></script>
-    <script src="https://cdn.jsdelivr.net/npm/chart.js@4.4.0/dist/chart.umd.min.js"></script>
-    <script src="https://cdn.jsdelivr.net/npm/html2canvas@1.4.1/dist/html2canvas.min.js"></script>
+    {{styles}}
+    {{script "chart.js"}}
+    {{script "html2canvas"}}
     <style>
         body {
             color: #000000;
@@ -76,6 +76,14 @@ const reportTemplate = `<!DOCTYPE html>
             background-color: #7f1d1d;
             color: #ffffff !important;
         }
+        .missing {
+            background-color: #fef9c3;
+            color: #000000;
+        }
+        .dark .missing {
+            background-color: #713f12;
+            color: #ffffff !important;
+        }
         .dark .unchanged {
             background-color: #374151;
             color: #ffffff !important;
@@ -122,6 +130,62 @@ const reportTemplate = `<!DOCTYPE html>
         </div>
     </div>
 
+    <!-- Headline -->
+    <div class="max-w-7xl mx-auto px-4 pt-6">
+        <div class="grid grid-cols-1 md:grid-cols-3 gap-6 mb-6">
+            <div class="bg-white rounded-lg shadow border border-gray-200 p-6">
+                <p class="text-sm font-medium text-gray-600">Solved by Both</p>
+                <p class="text-3xl font-bold text-gray-900 mt-1">{{.Aggregates.SolvedBoth}} / {{.Aggregates.CommonLevels}}</p>
+                <p class="text-xs text-gray-500 mt-1">common levels, used for the ratios below</p>
+            </div>
+            <div class="bg-white rounded-lg shadow border border-gray-200 p-6 {{if lt .Aggregates.PAR2Ratio 1.0}}improvement{{else if gt .Aggregates.PAR2Ratio 1.0}}regression{{else}}unchanged{{end}}">
+                <p class="text-sm font-medium text-gray-600">PAR-2 Score</p>
+                <p class="text-3xl font-bold text-gray-900 mt-1">{{printf "%.2fs" .Aggregates.PAR2Value1}} vs {{printf "%.2fs" .Aggregates.PAR2Value2}}</p>
+                <p class="text-xs text-gray-500 mt-1">ratio {{printf "%.3f" .Aggregates.PAR2Ratio}}, unsolved levels cost twice the timeout</p>
+            </div>
+            {{range .Aggregates.Metrics}}{{if eq .Metric "Time"}}
+            <div class="bg-white rounded-lg shadow border border-gray-200 p-6 {{if gt .GeoMeanSpeedup 1.0}}improvement{{else if and (lt .GeoMeanSpeedup 1.0) (gt .GeoMeanSpeedup 0.0)}}regression{{else}}unchanged{{end}}">
+                <p class="text-sm font-medium text-gray-600">Time Speedup (geometric mean)</p>
+                <p class="text-3xl font-bold text-gray-900 mt-1">{{printf "%.3f×" .GeoMeanSpeedup}}</p>
+                <p class="text-xs text-gray-500 mt-1">{{printf "%.0f%%" (percent .ImprovedFraction)}} of levels faster, {{printf "%.0f%%" (percent .RegressedFraction)}} slower</p>
+            </div>
+            {{end}}{{end}}
+        </div>
+        <div class="bg-white rounded-lg shadow border border-gray-200">
+            <div class="overflow-x-auto">
+                <table class="min-w-full divide-y divide-gray-200">
+                    <thead class="bg-gray-50">
+                        <tr>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Metric</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Levels</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Geo-mean Speedup</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Median Ratio</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Total Ratio</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Improved</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Regressed</th>
+                        </tr>
+                    </thead>
+                    <tbody class="bg-white divide-y divide-gray-200">
+                        {{range .Aggregates.Metrics}}
+                        <tr>
+                            <td class="px-6 py-3 whitespace-nowrap text-sm font-medium text-gray-900">{{.Metric}}</td>
+                            <td class="px-6 py-3 whitespace-nowrap text-sm text-gray-900">{{.Levels}}</td>
+                            <td class="px-6 py-3 whitespace-nowrap text-sm text-gray-900">{{if .RatioLevels}}{{printf "%.3f×" .GeoMeanSpeedup}}{{else}}n/a{{end}}</td>
+                            <td class="px-6 py-3 whitespace-nowrap text-sm text-gray-900">{{if .RatioLevels}}{{printf "%.3f" .MedianRatio}}{{else}}n/a{{end}}</td>
+                            <td class="px-6 py-3 whitespace-nowrap text-sm text-gray-900">{{if .TotalRatio}}{{printf "%.3f" .TotalRatio}}{{else}}n/a{{end}}</td>
+                            <td class="px-6 py-3 whitespace-nowrap text-sm text-green-600">{{printf "%.1f%%" (percent .ImprovedFraction)}}</td>
+                            <td class="px-6 py-3 whitespace-nowrap text-sm text-red-600">{{printf "%.1f%%" (percent .RegressedFraction)}}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+            <p class="px-6 py-3 text-xs text-gray-500 border-t border-gray-200">
+                Ratios are {{.Benchmark1Name}} / {{.Benchmark2Name}} over the levels solved by both: below 1 means {{.Benchmark1Name}} is lower. The speedup is the inverse of the geometric mean ratio.
+            </p>
+        </div>
+    </div>
+
     <!-- Info Banner -->
     <div class="max-w-7xl mx-auto px-4 py-6">
         <div class="bg-blue-50 dark:bg-blue-900 border-l-4 border-blue-500 p-4 rounded-lg">
@@ -142,6 +206,40 @@ const reportTemplate = `<!DOCTYPE html>
         </div>
     </div>
 
+    {{if or .OnlyInBenchmark1 .OnlyInBenchmark2}}
+    <!-- Levels Present In Only One Benchmark -->
+    <div class="max-w-7xl mx-auto px-4 py-2">
+        <div class="bg-white rounded-lg shadow border border-gray-200">
+            <div class="p-6 border-b border-gray-200">
+                <h2 class="text-2xl font-bold text-gray-900">Levels Present In Only One Benchmark</h2>
+                <p class="text-sm text-gray-600 mt-1">These levels cannot be compared and are shown as missing in the table below.</p>
+            </div>
+            <div class="grid grid-cols-1 md:grid-cols-2 gap-6 p-6">
+                <div>
+                    <h3 class="text-lg font-semibold text-blue-600 mb-2">Only in {{.Benchmark1Name}} ({{len .OnlyInBenchmark1}})</h3>
+                    {{if .OnlyInBenchmark1}}
+                    <ul class="text-sm text-gray-900 space-y-1">
+                        {{range .OnlyInBenchmark1}}<li class="missing px-2 py-1 rounded">{{.}}</li>{{end}}
+                    </ul>
+                    {{else}}
+                    <p class="text-sm text-gray-500">None</p>
+                    {{end}}
+                </div>
+                <div>
+                    <h3 class="text-lg font-semibold text-orange-600 mb-2">Only in {{.Benchmark2Name}} ({{len .OnlyInBenchmark2}})</h3>
+                    {{if .OnlyInBenchmark2}}
+                    <ul class="text-sm text-gray-900 space-y-1">
+                        {{range .OnlyInBenchmark2}}<li class="missing px-2 py-1 rounded">{{.}}</li>{{end}}
+                    </ul>
+                    {{else}}
+                    <p class="text-sm text-gray-500">None</p>
+                    {{end}}
+                </div>
+            </div>
+        </div>
+    </div>
+    {{end}}
+
     <!-- Comparison Table -->
     <div class="max-w-7xl mx-auto px-4 py-8">
         <div class="bg-white rounded-lg shadow border border-gray-200">
@@ -160,6 +258,7 @@ const reportTemplate = `<!DOCTYPE html>
                             <option value="all">All Levels</option>
                             <option value="improvement">Improvements Only</option>
                             <option value="regression">Regressions Only</option>
+                            <option value="missing">Missing Only</option>
                         </select>
                     </div>
                 </div>
@@ -193,42 +292,47 @@ const reportTemplate = `<!DOCTYPE html>
                     </thead>
                     <tbody class="bg-white divide-y divide-gray-200">
                         {{range .Levels}}
-                        <tr data-status="{{.Generated.Status}}">
+                        <tr data-status="{{if ne .Presence "both"}}missing{{else}}{{.Generated.Status}}{{end}}">
                             <td class="px-6 py-4 whitespace-nowrap text-sm font-medium text-gray-900">
                                 {{.LevelName}}
                             </td>
                             <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900 {{.Generated.Status}}">
-                                <div class="font-semibold">{{printf "%.0f vs %.0f" .Generated.Value1 .Generated.Value2}}</div>
+                                <div class="font-semibold">{{if .Generated.Missing1}}n/a{{else}}{{printf "%.0f" .Generated.Value1}}{{end}} vs {{if .Generated.Missing2}}n/a{{else}}{{printf "%.0f" .Generated.Value2}}{{end}}</div>
                                 <div class="text-xs {{if .Generated.IsImprovement}}text-green-600{{else if eq .Generated.Status "regression"}}text-red-600{{else}}text-gray-500{{end}}">
-                                    {{printf "%.0f (%.1f%%)" .Generated.Diff .Generated.DiffPct}}
+                                    {{if or .Generated.Missing1 .Generated.Missing2}}missing{{else}}{{printf "%.0f (%.1f%%)" .Generated.Diff .Generated.DiffPct}}{{end}}
                                 </div>
+                                {{template "significance" .Generated}}
                             </td>
                             <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900 {{.Explored.Status}}">
-                                <div class="font-semibold">{{printf "%.0f vs %.0f" .Explored.Value1 .Explored.Value2}}</div>
+                                <div class="font-semibold">{{if .Explored.Missing1}}n/a{{else}}{{printf "%.0f" .Explored.Value1}}{{end}} vs {{if .Explored.Missing2}}n/a{{else}}{{printf "%.0f" .Explored.Value2}}{{end}}</div>
                                 <div class="text-xs {{if .Explored.IsImprovement}}text-green-600{{else if eq .Explored.Status "regression"}}text-red-600{{else}}text-gray-500{{end}}">
-                                    {{printf "%.0f (%.1f%%)" .Explored.Diff .Explored.DiffPct}}
+                                    {{if or .Explored.Missing1 .Explored.Missing2}}missing{{else}}{{printf "%.0f (%.1f%%)" .Explored.Diff .Explored.DiffPct}}{{end}}
                                 </div>
+                                {{template "significance" .Explored}}
                             </td>
                             <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900 {{.MemoryAlloc.Status}}">
-                                <div class="font-semibold">{{printf "%.2f vs %.2f" .MemoryAlloc.Value1 .MemoryAlloc.Value2}}</div>
+                                <div class="font-semibold">{{if .MemoryAlloc.Missing1}}n/a{{else}}{{printf "%.2f" .MemoryAlloc.Value1}}{{end}} vs {{if .MemoryAlloc.Missing2}}n/a{{else}}{{printf "%.2f" .MemoryAlloc.Value2}}{{end}}</div>
                                 <div class="text-xs {{if .MemoryAlloc.IsImprovement}}text-green-600{{else if eq .MemoryAlloc.Status "regression"}}text-red-600{{else}}text-gray-500{{end}}">
-                                    {{printf "%.2f (%.1f%%)" .MemoryAlloc.Diff .MemoryAlloc.DiffPct}}
+                                    {{if or .MemoryAlloc.Missing1 .MemoryAlloc.Missing2}}missing{{else}}{{printf "%.2f (%.1f%%)" .MemoryAlloc.Diff .MemoryAlloc.DiffPct}}{{end}}
                                 </div>
+                                {{template "significance" .MemoryAlloc}}
                             </td>
                             <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900 {{.Time.Status}}">
-                                <div class="font-semibold">{{printf "%.3f vs %.3f" .Time.Value1 .Time.Value2}}</div>
+                                <div class="font-semibold">{{if .Time.Missing1}}n/a{{else}}{{printf "%.3f" .Time.Value1}}{{end}} vs {{if .Time.Missing2}}n/a{{else}}{{printf "%.3f" .Time.Value2}}{{end}}</div>
                                 <div class="text-xs {{if .Time.IsImprovement}}text-green-600{{else if eq .Time.Status "regression"}}text-red-600{{else}}text-gray-500{{end}}">
-                                    {{printf "%.3f (%.1f%%)" .Time.Diff .Time.DiffPct}}
+                                    {{if or .Time.Missing1 .Time.Missing2}}missing{{else}}{{printf "%.3f (%.1f%%)" .Time.Diff .Time.DiffPct}}{{end}}
                                 </div>
+                                {{template "significance" .Time}}
                             </td>
                             <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900 {{.Actions.Status}}">
-                                <div class="font-semibold">{{printf "%.0f vs %.0f" .Actions.Value1 .Actions.Value2}}</div>
+                                <div class="font-semibold">{{if .Actions.Missing1}}n/a{{else}}{{printf "%.0f" .Actions.Value1}}{{end}} vs {{if .Actions.Missing2}}n/a{{else}}{{printf "%.0f" .Actions.Value2}}{{end}}</div>
                                 <div class="text-xs {{if .Actions.IsImprovement}}text-green-600{{else if eq .Actions.Status "regression"}}text-red-600{{else}}text-gray-500{{end}}">
-                                    {{printf "%.0f (%.1f%%)" .Actions.Diff .Actions.DiffPct}}
+                                    {{if or .Actions.Missing1 .Actions.Missing2}}missing{{else}}{{printf "%.0f (%.1f%%)" .Actions.Diff .Actions.DiffPct}}{{end}}
                                 </div>
+                                {{template "significance" .Actions}}
                             </td>
                             <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900 {{.Solved.Status}}">
-                                <div class="font-semibold">{{.Solved.Solved1}} vs {{.Solved.Solved2}}</div>
+                                <div class="font-semibold">{{if .Solved.Missing1}}n/a{{else}}{{.Solved.Solved1}}{{end}} vs {{if .Solved.Missing2}}n/a{{else}}{{.Solved.Solved2}}{{end}}</div>
                             </td>
                         </tr>
                         {{end}}
@@ -238,6 +342,62 @@ const reportTemplate = `<!DOCTYPE html>
         </div>
     </div>
 
+    <!-- Statistical Significance -->
+    <div class="max-w-7xl mx-auto px-4 py-8">
+        <div class="bg-white rounded-lg shadow border border-gray-200">
+            <div class="p-6 border-b border-gray-200">
+                <h2 class="text-2xl font-bold text-gray-900">Statistical Significance</h2>
+                <p class="text-sm text-gray-600 mt-1">
+                    Wilcoxon signed-rank test over the levels solved by both benchmarks. A result is significant when its p-value is below the significance level.
+                </p>
+            </div>
+            <div class="overflow-x-auto">
+                <table class="min-w-full divide-y divide-gray-200">
+                    <thead class="bg-gray-50">
+                        <tr>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Metric</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Levels</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Lower in {{.Benchmark1Name}}</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Higher in {{.Benchmark1Name}}</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">p-value</th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Result</th>
+                        </tr>
+                    </thead>
+                    <tbody class="bg-white divide-y divide-gray-200">
+                        {{range .Significance}}
+                        <tr>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm font-medium text-gray-900">{{.Metric}}</td>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Levels}}</td>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Improved}}</td>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{.Regressed}}</td>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">{{printf "%.4f" .PValue}}</td>
+                            <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900 {{if eq .Label "significant improvement"}}improvement{{else if eq .Label "significant regression"}}regression{{else}}unchanged{{end}}">{{.Label}}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+            {{if .HasRepeatedRuns}}
+            <div class="p-6 border-t border-gray-200">
+                <div class="flex justify-between items-center mb-4">
+                    <div>
+                        <h3 class="text-xl font-semibold">Per-Level Confidence Intervals</h3>
+                        <p class="text-sm text-gray-600">Median difference ({{.Benchmark1Name}} - {{.Benchmark2Name}}) with its bootstrap confidence interval. Intervals crossing zero are inconclusive.</p>
+                    </div>
+                    <select id="ciMetricSelect" class="px-4 py-2 border border-gray-300 rounded-lg focus:outline-none focus:ring-2 focus:ring-blue-500" onchange="showCIChart(this.value)">
+                        <option value="time">Time</option>
+                        <option value="actions">Actions</option>
+                        <option value="generated">Generated</option>
+                        <option value="explored">Explored</option>
+                        <option value="memory">Memory</option>
+                    </select>
+                </div>
+                <canvas id="ciChart"></canvas>
+            </div>
+            {{end}}
+        </div>
+    </div>
+
     <!-- Charts Section -->
     <div class="max-w-7xl mx-auto px-4 py-8">
         <div class="bg-white rounded-lg shadow border border-gray-200">
@@ -327,16 +487,16 @@ const reportTemplate = `<!DOCTYPE html>
         // Chart data from Go template
         const chartData = {
             labels: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}"{{$v.LevelName}}"{{end}}],
-            generated1: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{$v.Generated.Value1}}{{end}}],
-            generated2: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{$v.Generated.Value2}}{{end}}],
-            explored1: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{$v.Explored.Value1}}{{end}}],
-            explored2: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{$v.Explored.Value2}}{{end}}],
-            memory1: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{$v.MemoryAlloc.Value1}}{{end}}],
-            memory2: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{$v.MemoryAlloc.Value2}}{{end}}],
-            time1: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{$v.Time.Value1}}{{end}}],
-            time2: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{$v.Time.Value2}}{{end}}],
-            actions1: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{$v.Actions.Value1}}{{end}}],
-            actions2: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{$v.Actions.Value2}}{{end}}],
+            generated1: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Generated.Missing1}}null{{else}}{{$v.Generated.Value1}}{{end}}{{end}}],
+            generated2: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Generated.Missing2}}null{{else}}{{$v.Generated.Value2}}{{end}}{{end}}],
+            explored1: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Explored.Missing1}}null{{else}}{{$v.Explored.Value1}}{{end}}{{end}}],
+            explored2: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Explored.Missing2}}null{{else}}{{$v.Explored.Value2}}{{end}}{{end}}],
+            memory1: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.MemoryAlloc.Missing1}}null{{else}}{{$v.MemoryAlloc.Value1}}{{end}}{{end}}],
+            memory2: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.MemoryAlloc.Missing2}}null{{else}}{{$v.MemoryAlloc.Value2}}{{end}}{{end}}],
+            time1: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Time.Missing1}}null{{else}}{{$v.Time.Value1}}{{end}}{{end}}],
+            time2: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Time.Missing2}}null{{else}}{{$v.Time.Value2}}{{end}}{{end}}],
+            actions1: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Actions.Missing1}}null{{else}}{{$v.Actions.Value1}}{{end}}{{end}}],
+            actions2: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Actions.Missing2}}null{{else}}{{$v.Actions.Value2}}{{end}}{{end}}],
         };
 
         const benchmark1Name = "{{.Benchmark1Name}}";
@@ -413,6 +573,67 @@ const reportTemplate = `<!DOCTYPE html>
         createChart('timeChart', 'Time', chartData.time1, chartData.time2);
         createChart('actionsChart', 'Actions', chartData.actions1, chartData.actions2);
 
+        {{if .HasRepeatedRuns}}
+        // Confidence intervals of repeated runs
+        const ciData = {
+            generated: {
+                diff: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Generated.Significance}}{{$v.Generated.Diff}}{{else}}null{{end}}{{end}}],
+                range: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Generated.Significance}}[{{$v.Generated.CILow}}, {{$v.Generated.CIHigh}}]{{else}}null{{end}}{{end}}],
+            },
+            explored: {
+                diff: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Explored.Significance}}{{$v.Explored.Diff}}{{else}}null{{end}}{{end}}],
+                range: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Explored.Significance}}[{{$v.Explored.CILow}}, {{$v.Explored.CIHigh}}]{{else}}null{{end}}{{end}}],
+            },
+            memory: {
+                diff: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.MemoryAlloc.Significance}}{{$v.MemoryAlloc.Diff}}{{else}}null{{end}}{{end}}],
+                range: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.MemoryAlloc.Significance}}[{{$v.MemoryAlloc.CILow}}, {{$v.MemoryAlloc.CIHigh}}]{{else}}null{{end}}{{end}}],
+            },
+            time: {
+                diff: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Time.Significance}}{{$v.Time.Diff}}{{else}}null{{end}}{{end}}],
+                range: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Time.Significance}}[{{$v.Time.CILow}}, {{$v.Time.CIHigh}}]{{else}}null{{end}}{{end}}],
+            },
+            actions: {
+                diff: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Actions.Significance}}{{$v.Actions.Diff}}{{else}}null{{end}}{{end}}],
+                range: [{{range $i, $v := .Levels}}{{if $i}}, {{end}}{{if $v.Actions.Significance}}[{{$v.Actions.CILow}}, {{$v.Actions.CIHigh}}]{{else}}null{{end}}{{end}}],
+            },
+        };
+
+        function showCIChart(metric) {
+            if (charts['ciChart']) {
+                charts['ciChart'].destroy();
+            }
+            const ctx = document.getElementById('ciChart').getContext('2d');
+            charts['ciChart'] = new Chart(ctx, {
+                type: 'bar',
+                data: {
+                    labels: chartData.labels,
+                    datasets: [
+                        {
+                            type: 'line',
+                            label: 'Median difference',
+                            data: ciData[metric].diff,
+                            showLine: false,
+                            pointRadius: 4,
+                            backgroundColor: 'rgb(59, 130, 246)',
+                            borderColor: 'rgb(59, 130, 246)'
+                        },
+                        {
+                            label: 'Confidence interval',
+                            data: ciData[metric].range,
+                            backgroundColor: 'rgba(107, 114, 128, 0.3)',
+                            borderColor: 'rgb(107, 114, 128)',
+                            borderWidth: 1,
+                            barPercentage: 0.3
+                        }
+                    ]
+                },
+                options: chartConfig.options
+            });
+        }
+
+        showCIChart('time');
+        {{end}}
+
         // Show/hide charts
         function showChart(metric) {
             // Hide all chart containers
@@ -515,4 +736,6 @@ const reportTemplate = `<!DOCTYPE html>
     </script>
 </body>
 </html>
-`
+{{define "significance"}}{{if .Significance}}<div class="text-xs text-gray-500" title="Mann-Whitney U p-value and bootstrap confidence interval of the median difference">
+                                    p={{printf "%.3f" .PValue}} CI [{{printf "%.3g" .CILow}}, {{printf "%.3g" .CIHigh}}]<br>{{.Significance}} ({{.Runs1}} vs {{.Runs2}} runs)
+                                </div>{{end}}{{end}}`
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
 	"sync"
 
 	"gopkg.in/yaml.v3"
@@ -65,4 +66,51 @@ func loadConfig(filePath ...string) {
 			_ = os.WriteFile(configPath, append(data, []byte(appendLine)...), 0644)
 		}
 	}
+
+	// Older configurations have no tolerances, fall back to the defaults
+	// for every metric that is not explicitly configured
+	tolerances := make(map[string]models.Tolerance, len(instance.Tolerances))
+	for metric, tolerance := range instance.Tolerances {
+		column, err := models.MetricColumn(metric)
+		if err == nil {
+			if _, ok := tolerances[column]; ok {
+				err = fmt.Errorf("%s is set twice", column)
+			}
+		}
+		if err != nil {
+			fmt.Printf("\033[31mError in your configuration: Tolerances: %v\033[0m\n", err)
+			os.Exit(1)
+		}
+		tolerances[column] = tolerance
+	}
+	instance.Tolerances = tolerances
+	for metric, tolerance := range models.DefaultConfiguration.Tolerances {
+		if _, ok := instance.Tolerances[metric]; !ok {
+			instance.Tolerances[metric] = tolerance
+		}
+	}
+
+	if len(instance.Check.Rules) == 0 {
+		instance.Check.Rules = models.DefaultConfiguration.Check.Rules
+	}
+
+	if len(instance.Scoring) == 0 {
+		instance.Scoring = models.DefaultConfiguration.Scoring
+	}
+
+	if len(instance.ParetoMetrics) == 0 {
+		instance.ParetoMetrics = slices.Clone(models.DefaultConfiguration.ParetoMetrics)
+	}
+	for i, metric := range instance.ParetoMetrics {
+		column, err := models.MetricColumn(metric)
+		if err != nil {
+			fmt.Printf("\033[31mError in your configuration: ParetoMetrics: %v\033[0m\n", err)
+			os.Exit(1)
+		}
+		instance.ParetoMetrics[i] = column
+	}
+
+	if instance.SignificanceLevel <= 0 || instance.SignificanceLevel >= 1 {
+		instance.SignificanceLevel = models.DefaultConfiguration.SignificanceLevel
+	}
 }
@@ -0,0 +1,61 @@
+package index
+
+import (
+	"slices"
+	"strings"
+)
+
+// Dependents returns the existing reports covering a benchmark, which are
+// stale once the benchmark is removed or renamed. Reports written into the
+// folder of another benchmark belong to it and are left out
+func Dependents(folder, name string) ([]Report, error) {
+	reports, err := existingReports(folder)
+	if err != nil {
+		return nil, err
+	}
+	benchmarks, err := BenchmarkFolders(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	var dependents []Report
+	for _, report := range reports {
+		if !slices.Contains(report.Benchmarks, name) {
+			continue
+		}
+		top, _, nested := strings.Cut(report.Path, "/")
+		if nested && top != name && slices.Contains(benchmarks, top) {
+			continue
+		}
+		dependents = append(dependents, report)
+	}
+	return dependents, nil
+}
+
+// comparisonBenchmarks returns the benchmarks of a comparison from the name
+// of its folder, <candidate>[+<candidate>...]vs<baseline>, for comparisons
+// written before reports were recorded. They are in the recorded order, the
+// pair or the baseline followed by the candidates. Names may contain "vs"
+// themselves, so the folder name is only split where both sides are known
+// benchmarks
+func comparisonBenchmarks(dir string, names []string) []string {
+	for i := strings.Index(dir, "vs"); i >= 0; {
+		candidates := strings.Split(dir[:i], "+")
+		baseline := dir[i+len("vs"):]
+		if slices.Contains(names, baseline) && !slices.ContainsFunc(candidates, func(candidate string) bool {
+			return !slices.Contains(names, candidate)
+		}) {
+			if len(candidates) == 1 {
+				return append(candidates, baseline)
+			}
+			return append([]string{baseline}, candidates...)
+		}
+
+		next := strings.Index(dir[i+1:], "vs")
+		if next < 0 {
+			break
+		}
+		i += 1 + next
+	}
+	return nil
+}
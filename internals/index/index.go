@@ -0,0 +1,389 @@
+package index
+
+import (
+	"fmt"
+	"html/template"
+	"masbench/internals/assets"
+	"masbench/internals/models"
+	"masbench/internals/utils"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReportsFile lists the reports written in the benchmark folder
+const ReportsFile = "reports.yml"
+
+// IndexFile is the index page written in the benchmark folder
+const IndexFile = "index.html"
+
+// ReportsMarker marks the folders holding reports, so that they are not
+// mistaken for benchmarks
+const ReportsMarker = ".masbench-reports"
+
+// reportFolders are the folders reports are written to by default, with the
+// kind of their reports. Reports found there but missing from ReportsFile,
+// such as reports written by older versions, are listed too
+var reportFolders = map[string]string{
+	"comparisons": KindComparison,
+	"summaries":   KindSummary,
+	"trends":      KindTrend,
+}
+
+// reportExtensions are the extensions of report files
+var reportExtensions = []string{".html", ".md", ".json"}
+
+// BenchmarkNames returns the name of every benchmark with results in folder,
+// sorted
+func BenchmarkNames(folder string) ([]string, error) {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", folder, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(resultsPath(folder, entry.Name())); err == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// BenchmarkFolders returns the name of every folder of a benchmark in
+// folder, sorted, including benchmarks without results such as interrupted
+// runs. Hidden folders and folders marked with ReportsMarker are left out,
+// unless they hold results
+func BenchmarkFolders(folder string) ([]string, error) {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", folder, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		if _, err := os.Stat(resultsPath(folder, name)); err != nil {
+			if _, err := os.Stat(filepath.Join(folder, name, ReportsMarker)); err == nil {
+				continue
+			}
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// markReportFolder marks the folder of the benchmark folder a report was
+// written to. Reports written directly to the benchmark folder, or into the
+// folder of a benchmark, leave it unmarked
+func markReportFolder(folder, relative string) error {
+	top, _, nested := strings.Cut(relative, "/")
+	if !nested || isBenchmark(folder, top) {
+		return nil
+	}
+	marker := filepath.Join(folder, top, ReportsMarker)
+	if err := os.WriteFile(marker, nil, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", marker, err)
+	}
+	return nil
+}
+
+// MarkLegacyReportFolders marks the default report folders written by older
+// versions, which have no marker, once they are found holding reports. It
+// runs when reports are recorded or the index is written, and before
+// commands changing benchmarks select them, listing never writes
+func MarkLegacyReportFolders(folder string) error {
+	for dir := range reportFolders {
+		if isBenchmark(folder, dir) {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(folder, dir, ReportsMarker)); err == nil {
+			continue
+		}
+		holdsReports := false
+		filepath.WalkDir(filepath.Join(folder, dir), func(path string, entry os.DirEntry, err error) error {
+			if err == nil && !entry.IsDir() && isReportFile(path) {
+				holdsReports = true
+				return filepath.SkipAll
+			}
+			return nil
+		})
+		if !holdsReports {
+			continue
+		}
+		if err := markReportFolder(folder, dir+"/"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isBenchmark reports whether the folder name holds the results or the
+// metadata of a benchmark
+func isBenchmark(folder, name string) bool {
+	if _, err := os.Stat(resultsPath(folder, name)); err == nil {
+		return true
+	}
+	_, err := os.Stat(utils.MetadataPath(folder, name))
+	return err == nil
+}
+
+func resultsPath(folder, name string) string {
+	return filepath.Join(folder, name, fmt.Sprintf("%s_results.csv", name))
+}
+
+// LoadReports reads the reports recorded in the benchmark folder
+func LoadReports(folder string) ([]Report, error) {
+	data, err := os.ReadFile(filepath.Join(folder, ReportsFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ReportsFile, err)
+	}
+
+	var reports []Report
+	if err := yaml.Unmarshal(data, &reports); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ReportsFile, err)
+	}
+	return reports, nil
+}
+
+// SaveReports writes the reports recorded in the benchmark folder
+func SaveReports(folder string, reports []Report) error {
+	data, err := yaml.Marshal(reports)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", ReportsFile, err)
+	}
+	if err := os.WriteFile(filepath.Join(folder, ReportsFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ReportsFile, err)
+	}
+	return nil
+}
+
+// RecordReport adds a report written to path to the reports of the folder,
+// replacing the previous report written to the same path. Reports written
+// outside the folder are not recorded, masbench does not manage them
+func RecordReport(folder, path string, report Report) error {
+	relative, err := relativePath(folder, path)
+	if err != nil {
+		return err
+	}
+	if !isInside(relative) {
+		return nil
+	}
+	report.Path = relative
+	if err := markReportFolder(folder, relative); err != nil {
+		return err
+	}
+	if err := MarkLegacyReportFolders(folder); err != nil {
+		return err
+	}
+
+	reports, err := LoadReports(folder)
+	if err != nil {
+		return err
+	}
+	kept := reports[:0]
+	for _, existing := range reports {
+		if existing.Path != report.Path {
+			kept = append(kept, existing)
+		}
+	}
+	return SaveReports(folder, append(kept, report))
+}
+
+// relativePath returns path relative to folder, with forward slashes
+func relativePath(folder, path string) (string, error) {
+	absFolder, err := filepath.Abs(folder)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", folder, err)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	relative, err := filepath.Rel(absFolder, absPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	return filepath.ToSlash(relative), nil
+}
+
+// isInside reports whether a path relative to the benchmark folder stays
+// inside it
+func isInside(relative string) bool {
+	return filepath.IsLocal(filepath.FromSlash(relative))
+}
+
+// Generate writes the index page of the benchmark folder, listing every
+// benchmark and every report that still exists
+func Generate(folder string) error {
+	idx := Index{GeneratedAt: time.Now().Format("2006-01-02 15:04:05")}
+	if err := MarkLegacyReportFolders(folder); err != nil {
+		return err
+	}
+
+	names, err := BenchmarkNames(folder)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		benchmark, err := LoadBenchmark(folder, name)
+		if err != nil {
+			return err
+		}
+		idx.Benchmarks = append(idx.Benchmarks, benchmark)
+	}
+	sort.SliceStable(idx.Benchmarks, func(i, j int) bool {
+		return idx.Benchmarks[i].StartedAt.After(idx.Benchmarks[j].StartedAt)
+	})
+
+	if idx.Reports, err = existingReports(folder); err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("index").Funcs(template.FuncMap{
+		"join":   strings.Join,
+		"styles": assets.Styles,
+	}).Parse(indexTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	file, err := os.Create(filepath.Join(folder, IndexFile))
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", IndexFile, err)
+	}
+	defer file.Close()
+
+	if err := tmpl.Execute(file, idx); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return nil
+}
+
+// LoadBenchmark reads the description, metadata and headline numbers of a
+// benchmark
+func LoadBenchmark(folder, name string) (Benchmark, error) {
+	meta, err := utils.LoadMetadata(folder, name)
+	if err != nil {
+		return Benchmark{}, err
+	}
+	df, err := utils.LoadCSV(resultsPath(folder, name))
+	if err != nil {
+		return Benchmark{}, err
+	}
+
+	benchmark := Benchmark{
+		Name:        name,
+		Description: Description(folder, name),
+		StartedAt:   meta.StartedAt,
+		Tags:        meta.Tags,
+		Algorithm:   meta.Algorithm,
+		ResultsPath: filepath.ToSlash(filepath.Join(name, fmt.Sprintf("%s_results.csv", name))),
+	}
+	for _, rows := range utils.ToRowsMap(df) {
+		benchmark.LevelsTotal++
+		runs := utils.ResolveRuns(rows)
+		if !runs.Solved() {
+			continue
+		}
+		levelTime, _ := runs.Median(models.ColTime)
+		levelActions, _ := runs.Median(models.ColActions)
+		benchmark.LevelsSolved++
+		benchmark.TotalTime += levelTime
+		benchmark.TotalActions += levelActions
+	}
+	return benchmark, nil
+}
+
+// Description returns the message a benchmark was run with
+func Description(folder, name string) string {
+	data, err := os.ReadFile(filepath.Join(folder, name, name+".md"))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// existingReports returns the recorded reports whose file still exists,
+// and the unrecorded report files of the report folders, most recent first
+func existingReports(folder string) ([]Report, error) {
+	recorded, err := LoadReports(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []Report
+	known := make(map[string]bool)
+	for _, report := range recorded {
+		// Older versions also recorded reports written outside the folder
+		if !isInside(report.Path) {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(folder, filepath.FromSlash(report.Path))); err == nil {
+			reports = append(reports, report)
+			known[report.Path] = true
+		}
+	}
+
+	names, err := BenchmarkNames(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	for dir, kind := range reportFolders {
+		root := filepath.Join(folder, dir)
+		err := filepath.WalkDir(root, func(path string, entry os.DirEntry, err error) error {
+			if err != nil || entry.IsDir() || !isReportFile(path) {
+				return nil
+			}
+			relative, err := relativePath(folder, path)
+			if err != nil || known[relative] {
+				return nil
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return nil
+			}
+			report := Report{Kind: kind, Path: relative, CreatedAt: info.ModTime()}
+			if kind == KindComparison {
+				comparison, _, _ := strings.Cut(strings.TrimPrefix(relative, dir+"/"), "/")
+				report.Benchmarks = comparisonBenchmarks(comparison, names)
+			}
+			reports = append(reports, report)
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", root, err)
+		}
+	}
+
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].CreatedAt.After(reports[j].CreatedAt)
+	})
+	return reports, nil
+}
+
+func isReportFile(path string) bool {
+	extension := filepath.Ext(path)
+	for _, reportExtension := range reportExtensions {
+		if extension == reportExtension {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package index
+
+import "time"
+
+// Kinds of report listed in the index, besides the JSON report kinds
+const (
+	KindComparison = "comparison"
+	KindSummary    = "summary"
+	KindTrend      = "trend"
+)
+
+// Report is a report written in the benchmark folder. Path is relative to
+// the benchmark folder, with forward slashes
+type Report struct {
+	Kind       string    `yaml:"Kind"`
+	Path       string    `yaml:"Path"`
+	Benchmarks []string  `yaml:"Benchmarks,omitempty"`
+	CreatedAt  time.Time `yaml:"CreatedAt"`
+	Headline   string    `yaml:"Headline,omitempty"`
+}
+
+// Benchmark is a benchmark listed in the index with its headline numbers
+type Benchmark struct {
+	Name         string
+	Description  string
+	StartedAt    time.Time
+	Tags         []string
+	Algorithm    string
+	LevelsSolved int
+	LevelsTotal  int
+	TotalTime    float64 // over the solved levels
+	TotalActions float64 // over the solved levels
+	ResultsPath  string  // relative to the benchmark folder
+}
+
+// Index is the content of the index page of the benchmark folder
+type Index struct {
+	GeneratedAt string
+	Benchmarks  []Benchmark // most recent first
+	Reports     []Report    // most recent first
+}
@@ -0,0 +1,146 @@
+package index
+
+const indexTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Masbench Reports</title>
+    {{styles}}
+    <style>
+        body {
+            color: #000000;
+            background-color: #f9fafb;
+        }
+        .badge {
+            display: inline-block;
+            padding: 0.25rem 0.75rem;
+            border-radius: 9999px;
+            font-size: 0.75rem;
+            font-weight: 600;
+        }
+        .badge-comparison {
+            background-color: #dbeafe;
+            color: #1e40af;
+        }
+        .badge-summary {
+            background-color: #dcfce7;
+            color: #166534;
+        }
+        .badge-trend {
+            background-color: #f3e8ff;
+            color: #6b21a8;
+        }
+    </style>
+</head>
+<body class="bg-gray-50">
+    <!-- Header -->
+    <div class="bg-white shadow-sm border-b border-gray-200">
+        <div class="max-w-7xl mx-auto px-4 py-6">
+            <div class="flex justify-between items-center">
+                <div>
+                    <h1 class="text-3xl font-bold text-gray-900">🗂️ Masbench Reports</h1>
+                    <p class="mt-2 text-sm text-gray-600">
+                        {{len .Benchmarks}} benchmarks • {{len .Reports}} reports • updated {{.GeneratedAt}}
+                    </p>
+                </div>
+                <input
+                    type="text"
+                    id="searchInput"
+                    placeholder="🔍 Search..."
+                    class="px-4 py-2 border border-gray-300 rounded-lg focus:outline-none focus:ring-2 focus:ring-blue-500"
+                    onkeyup="filterRows()"
+                >
+            </div>
+        </div>
+    </div>
+
+    <!-- Reports -->
+    <div class="max-w-7xl mx-auto px-4 py-8">
+        <div class="bg-white rounded-lg shadow border border-gray-200">
+            <div class="p-6 border-b border-gray-200">
+                <h2 class="text-2xl font-bold text-gray-900">📑 Reports</h2>
+            </div>
+            <div class="overflow-x-auto">
+                <table class="min-w-full divide-y divide-gray-200">
+                    <thead class="bg-gray-50">
+                        <tr>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Kind</th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Report</th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Benchmarks</th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Date</th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Headline</th>
+                        </tr>
+                    </thead>
+                    <tbody class="bg-white divide-y divide-gray-200">
+                        {{range .Reports}}
+                        <tr class="searchable">
+                            <td class="px-4 py-2 text-sm"><span class="badge badge-{{.Kind}}">{{.Kind}}</span></td>
+                            <td class="px-4 py-2 text-sm font-medium"><a class="text-blue-600 hover:underline" href="{{.Path}}">{{.Path}}</a></td>
+                            <td class="px-4 py-2 text-sm text-gray-900">{{join .Benchmarks ", "}}</td>
+                            <td class="px-4 py-2 text-sm text-gray-600 whitespace-nowrap">{{.CreatedAt.Format "2006-01-02 15:04"}}</td>
+                            <td class="px-4 py-2 text-sm text-gray-600">{{.Headline}}</td>
+                        </tr>
+                        {{else}}
+                        <tr>
+                            <td colspan="5" class="px-4 py-4 text-sm text-gray-400">No reports yet, create one with masbench compare or masbench summary.</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+        </div>
+    </div>
+
+    <!-- Benchmarks -->
+    <div class="max-w-7xl mx-auto px-4 py-8">
+        <div class="bg-white rounded-lg shadow border border-gray-200">
+            <div class="p-6 border-b border-gray-200">
+                <h2 class="text-2xl font-bold text-gray-900">🏃 Benchmarks</h2>
+            </div>
+            <div class="overflow-x-auto">
+                <table class="min-w-full divide-y divide-gray-200">
+                    <thead class="bg-gray-50">
+                        <tr>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Benchmark</th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Description</th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Date</th>
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Solved</th>
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Time (solved)</th>
+                        </tr>
+                    </thead>
+                    <tbody class="bg-white divide-y divide-gray-200">
+                        {{range .Benchmarks}}
+                        <tr class="searchable">
+                            <td class="px-4 py-2 text-sm font-medium">
+                                <a class="text-blue-600 hover:underline" href="{{.ResultsPath}}">{{.Name}}</a>
+                                {{range .Tags}}<span class="badge badge-trend ml-1">{{.}}</span>{{end}}
+                            </td>
+                            <td class="px-4 py-2 text-sm text-gray-600">{{.Description}}</td>
+                            <td class="px-4 py-2 text-sm text-gray-600 whitespace-nowrap">{{.StartedAt.Format "2006-01-02 15:04"}}</td>
+                            <td class="px-4 py-2 text-sm text-right text-gray-900">{{.LevelsSolved}} / {{.LevelsTotal}}</td>
+                            <td class="px-4 py-2 text-sm text-right text-gray-900">{{printf "%.2fs" .TotalTime}}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+        </div>
+    </div>
+
+    <!-- Footer -->
+    <div class="max-w-7xl mx-auto px-4 py-8 text-center text-sm text-gray-500">
+        <p>Generated by masbench, updated after every command</p>
+    </div>
+
+    <script>
+        function filterRows() {
+            const searchValue = document.getElementById('searchInput').value.toLowerCase();
+            document.querySelectorAll('tr.searchable').forEach(row => {
+                row.style.display = row.textContent.toLowerCase().includes(searchValue) ? '' : 'none';
+            });
+        }
+    </script>
+</body>
+</html>
+`
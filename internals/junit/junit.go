@@ -0,0 +1,234 @@
+// Package junit writes benchmark results as JUnit XML, the test report
+// format rendered natively by CI systems
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"masbench/internals/comparator"
+	"masbench/internals/models"
+	"masbench/internals/utils"
+	"strconv"
+	"strings"
+
+	"github.com/go-gota/gota/dataframe"
+)
+
+// Failure types of a testcase
+const (
+	FailureUnsolved      = "unsolved"
+	FailureNewlyUnsolved = "newly-unsolved"
+	FailureRegression    = "regression"
+)
+
+// TestSuites is the root element of a JUnit report
+type TestSuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Time     float64     `xml:"time,attr"`
+	Suites   []TestSuite `xml:"testsuite"`
+}
+
+// TestSuite groups the testcases of one benchmark
+type TestSuite struct {
+	Name       string      `xml:"name,attr"`
+	Tests      int         `xml:"tests,attr"`
+	Failures   int         `xml:"failures,attr"`
+	Skipped    int         `xml:"skipped,attr"`
+	Time       float64     `xml:"time,attr"`
+	Properties *Properties `xml:"properties,omitempty"`
+	TestCases  []TestCase  `xml:"testcase"`
+}
+
+// TestCase is a single level
+type TestCase struct {
+	Name       string      `xml:"name,attr"`
+	ClassName  string      `xml:"classname,attr"`
+	Time       float64     `xml:"time,attr"`
+	Properties *Properties `xml:"properties,omitempty"`
+	Failure    *Failure    `xml:"failure,omitempty"`
+	Skipped    *Skipped    `xml:"skipped,omitempty"`
+}
+
+// Properties of a suite or testcase, omitted when there are none
+type Properties struct {
+	Items []Property `xml:"property"`
+}
+
+// Property is a name/value pair attached to a suite or testcase
+type Property struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// Failure marks a failed testcase
+type Failure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Skipped marks a testcase that was not run
+type Skipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// FromResults builds a suite with one testcase per level of a results CSV,
+// failing the levels that were not solved
+func FromResults(name string, df dataframe.DataFrame) TestSuite {
+	suite := TestSuite{Name: name}
+	rowsByLevel := utils.ToRowsMap(df)
+
+	seen := make(map[string]bool)
+	for _, level := range df.Col(models.ColLevelName).Records() {
+		if seen[level] {
+			continue
+		}
+		seen[level] = true
+
+		runs := utils.ResolveRuns(rowsByLevel[level])
+		levelTime, _ := runs.Median(models.ColTime)
+		actions, _ := runs.Median(models.ColActions)
+		explored, _ := runs.Median(models.ColExplored)
+		generated, _ := runs.Median(models.ColGenerated)
+
+		testCase := TestCase{
+			Name:      level,
+			ClassName: name,
+			Time:      levelTime,
+		}
+		testCase.addProperty("time", formatFloat(levelTime))
+		testCase.addProperty("actions", formatFloat(actions))
+		testCase.addProperty("explored", formatFloat(explored))
+		testCase.addProperty("generated", formatFloat(generated))
+		if len(runs.Rows) > 1 {
+			testCase.addProperty("runs", strconv.Itoa(len(runs.Rows)))
+		}
+		if !runs.Solved() {
+			testCase.Failure = &Failure{
+				Message: "level not solved",
+				Type:    FailureUnsolved,
+				Text:    fmt.Sprintf("%s was solved in %d of %d runs", level, runs.SolvedRuns, len(runs.Rows)),
+			}
+		}
+
+		suite.add(testCase)
+	}
+
+	return suite
+}
+
+// FromComparison builds a suite with one testcase per level of a comparison
+// of a candidate (benchmark1) against a baseline (benchmark2). A testcase
+// fails when the candidate did not solve the level or when any metric
+// regressed beyond the tolerances, levels the candidate did not run are skipped
+func FromComparison(levels []comparator.LevelComparison, candidate, baseline string) TestSuite {
+	suite := TestSuite{
+		Name:       candidate,
+		Properties: &Properties{Items: []Property{{Name: "baseline", Value: baseline}}},
+	}
+
+	for i := range levels {
+		level := &levels[i]
+		testCase := TestCase{
+			Name:      level.LevelName,
+			ClassName: candidate,
+			Time:      level.Time.Value1,
+		}
+		for _, property := range []struct {
+			name   string
+			metric comparator.MetricComparison
+		}{
+			{"time", level.Time},
+			{"actions", level.Actions},
+			{"explored", level.Explored},
+			{"generated", level.Generated},
+		} {
+			if !property.metric.Missing1 {
+				testCase.addProperty(property.name, formatFloat(property.metric.Value1))
+			}
+		}
+
+		switch {
+		case level.Presence == comparator.PresenceOnly2:
+			testCase.Skipped = &Skipped{Message: fmt.Sprintf("level not run by %s", candidate)}
+		case level.Solved.Solved1 != models.SolvedYes:
+			testCase.Failure = &Failure{Message: "level not solved", Type: FailureUnsolved}
+			if level.Solved.Status == comparator.StatusRegression {
+				testCase.Failure = &Failure{Message: fmt.Sprintf("level solved by %s but not by %s", baseline, candidate), Type: FailureNewlyUnsolved}
+			}
+		default:
+			var regressed, details []string
+			for j, metric := range level.Metrics() {
+				if metric.Status != comparator.StatusRegression {
+					continue
+				}
+				regressed = append(regressed, models.MetricColumns[j])
+				details = append(details, fmt.Sprintf("%s regressed by %+.1f%% (%g vs %g)",
+					models.MetricColumns[j], metric.DiffPct, metric.Value1, metric.Value2))
+			}
+			if len(regressed) > 0 {
+				testCase.Failure = &Failure{
+					Message: fmt.Sprintf("%s regressed against %s", strings.Join(regressed, ", "), baseline),
+					Type:    FailureRegression,
+					Text:    strings.Join(details, "\n"),
+				}
+			}
+		}
+
+		suite.add(testCase)
+	}
+
+	return suite
+}
+
+// Write writes the suites as an indented JUnit XML document
+func Write(w io.Writer, name string, suites ...TestSuite) error {
+	report := TestSuites{Name: name, Suites: suites}
+	for _, suite := range suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+		report.Time += suite.Time
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// add appends a testcase and updates the counters of the suite
+func (s *TestSuite) add(testCase TestCase) {
+	s.TestCases = append(s.TestCases, testCase)
+	s.Tests++
+	s.Time += testCase.Time
+	if testCase.Failure != nil {
+		s.Failures++
+	}
+	if testCase.Skipped != nil {
+		s.Skipped++
+	}
+}
+
+// addProperty attaches a property to the testcase
+func (t *TestCase) addProperty(name, value string) {
+	if t.Properties == nil {
+		t.Properties = &Properties{}
+	}
+	t.Properties.Items = append(t.Properties.Items, Property{Name: name, Value: value})
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,89 @@
+// Package markdown writes GitHub-flavored Markdown fragments
+package markdown
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Status markers used in reports
+const (
+	MarkImprovement = "🟢"
+	MarkRegression  = "🔴"
+	MarkUnchanged   = "⚪"
+	MarkMissing     = "🟡"
+	MarkSolved      = "✅"
+	MarkUnsolved    = "❌"
+)
+
+// Escape makes text safe to use inside a table cell
+func Escape(text string) string {
+	replacer := strings.NewReplacer("|", "\\|", "\n", " ", "*", "\\*", "_", "\\_", "`", "\\`")
+	return replacer.Replace(text)
+}
+
+// Code wraps text in an inline code span
+func Code(text string) string {
+	return "`" + strings.ReplaceAll(text, "`", "'") + "`"
+}
+
+// Column of a Markdown table
+type Column struct {
+	Header     string
+	AlignRight bool
+}
+
+// Table is a GitHub-flavored Markdown table, cells are written as given
+type Table struct {
+	Columns []Column
+	Rows    [][]string
+}
+
+// NewTable returns an empty table with the given columns
+func NewTable(columns ...Column) *Table {
+	return &Table{Columns: columns}
+}
+
+// AddRow appends a row to the table
+func (t *Table) AddRow(cells ...string) {
+	t.Rows = append(t.Rows, cells)
+}
+
+// Write writes the table followed by a blank line
+func (t *Table) Write(w io.Writer) error {
+	headers := make([]string, len(t.Columns))
+	separators := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		headers[i] = col.Header
+		separators[i] = "---"
+		if col.AlignRight {
+			separators[i] = "---:"
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, "| %s |\n| %s |\n", strings.Join(headers, " | "), strings.Join(separators, " | ")); err != nil {
+		return err
+	}
+	for _, row := range t.Rows {
+		cells := make([]string, len(t.Columns))
+		copy(cells, row)
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | ")); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// Details writes a collapsible section, body is called to write its content
+func Details(w io.Writer, summary string, body func(io.Writer) error) error {
+	if _, err := fmt.Fprintf(w, "<details>\n<summary>%s</summary>\n\n", summary); err != nil {
+		return err
+	}
+	if err := body(w); err != nil {
+		return err
+	}
+	_, err := fmt.Fprint(w, "</details>\n\n")
+	return err
+}
@@ -1,13 +1,53 @@
 package models
 
+import "math"
+
 // Config holds the application configuration settings.
 type Config struct {
-	ServerPath          string `yaml:"ServerPath"`
-	LevelsDir           string `yaml:"LevelsDir"`
-	BenchmarkFolder     string `yaml:"BenchmarkFolder"`
-	ClientCommand       string `yaml:"ClientCommand"`
-	Timeout             int    `yaml:"Timeout"`
-	AlgorithmFlagFormat string `yaml:"AlgorithmFlagFormat"`
+	ServerPath          string               `yaml:"ServerPath"`
+	LevelsDir           string               `yaml:"LevelsDir"`
+	BenchmarkFolder     string               `yaml:"BenchmarkFolder"`
+	ClientCommand       string               `yaml:"ClientCommand"`
+	Timeout             int                  `yaml:"Timeout"`
+	AlgorithmFlagFormat string               `yaml:"AlgorithmFlagFormat"`
+	Tolerances          map[string]Tolerance `yaml:"Tolerances,omitempty"`
+	SignificanceLevel   float64              `yaml:"SignificanceLevel,omitempty"`
+	ParetoMetrics       []string             `yaml:"ParetoMetrics,omitempty"`
+	Check               CheckConfig          `yaml:"Check,omitempty"`
+	Scoring             []ScoringRule        `yaml:"Scoring,omitempty"`
+}
+
+// ScoringRule scores a metric on every solved level with a formula of the
+// value of the benchmark (own), the best and worst values among the
+// benchmarks that solved it and the timeout, e.g. "best/own". Unsolved
+// levels score 0. The score of a level is the weighted sum of the rules
+type ScoringRule struct {
+	Metric  string  `yaml:"Metric" json:"metric"`
+	Formula string  `yaml:"Formula" json:"formula"`
+	Weight  float64 `yaml:"Weight,omitempty" json:"weight"`
+}
+
+// CheckConfig holds the rules enforced by the check command. Allow maps a
+// level name to the metrics ("solved", "time", ... or "all") whose
+// regressions are ignored on that level
+type CheckConfig struct {
+	Rules []string            `yaml:"Rules,omitempty"`
+	Allow map[string][]string `yaml:"Allow,omitempty"`
+}
+
+// Tolerance defines how much a metric may change before the change is
+// considered more than noise. Relative is a fraction of the reference
+// value (0.05 = 5%), Absolute is expressed in the unit of the metric.
+type Tolerance struct {
+	Relative float64 `yaml:"Relative,omitempty"`
+	Absolute float64 `yaml:"Absolute,omitempty"`
+}
+
+// Within reports whether value differs from reference by no more than
+// the tolerance, using whichever of the two bounds is larger
+func (t Tolerance) Within(value, reference float64) bool {
+	bound := math.Max(t.Absolute, t.Relative*math.Abs(reference))
+	return math.Abs(value-reference) <= bound
 }
 
 var DefaultConfiguration Config = Config{
@@ -17,4 +57,16 @@ var DefaultConfiguration Config = Config{
 	ClientCommand:       "your_client_command --level {level_path}",
 	Timeout:             180,
 	AlgorithmFlagFormat: "-%s",
+	Tolerances: map[string]Tolerance{
+		ColTime: {Absolute: 0.1},
+	},
+	SignificanceLevel: 0.05,
+	ParetoMetrics:     []string{ColTime, ColActions},
+	Scoring: []ScoringRule{
+		{Metric: ColActions, Formula: "best/own", Weight: 1},
+		{Metric: ColTime, Formula: "best/own", Weight: 1},
+	},
+	Check: CheckConfig{
+		Rules: []string{"solved >= baseline", "no newly-unsolved"},
+	},
 }
@@ -1,5 +1,10 @@
 package models
 
+import (
+	"fmt"
+	"strings"
+)
+
 // CSV column names used across benchmark data files
 const (
 	ColLevelName   = "LevelName"
@@ -17,3 +22,20 @@ const (
 	SolvedYes = "Yes"
 	SolvedNo  = "No"
 )
+
+// MetricColumns are the compared metric columns, in the order used by reports
+var MetricColumns = []string{ColGenerated, ColExplored, ColMemoryAlloc, ColTime, ColActions}
+
+// MetricColumn returns the CSV column of a metric name, ignoring case.
+// "memory" is accepted for MemoryAlloc
+func MetricColumn(name string) (string, error) {
+	if strings.EqualFold(name, "memory") {
+		return ColMemoryAlloc, nil
+	}
+	for _, column := range MetricColumns {
+		if strings.EqualFold(column, name) {
+			return column, nil
+		}
+	}
+	return "", fmt.Errorf("unknown metric %q, expected one of %s", name, strings.Join(MetricColumns, ", "))
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// RunMetadata describes how and when a benchmark was run, it is saved next
+// to the results of the benchmark
+type RunMetadata struct {
+	Name       string    `yaml:"Name"`
+	Algorithm  string    `yaml:"Algorithm,omitempty"`
+	Repeat     int       `yaml:"Repeat,omitempty"`
+	Timeout    int       `yaml:"Timeout,omitempty"`
+	StartedAt  time.Time `yaml:"StartedAt"`
+	FinishedAt time.Time `yaml:"FinishedAt,omitempty"`
+	Tags       []string  `yaml:"Tags,omitempty"`
+}
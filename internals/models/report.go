@@ -0,0 +1,23 @@
+package models
+
+// ReportSchemaVersion is the version of the JSON report schema. It is bumped
+// whenever a field is renamed, removed or changes meaning; new fields may be
+// added without bumping it
+const ReportSchemaVersion = 2
+
+// Kinds of report exported as JSON
+const (
+	ReportKindComparison      = "comparison"
+	ReportKindMultiComparison = "multi-comparison"
+	ReportKindSummary         = "summary"
+	ReportKindTrend           = "trend"
+)
+
+// JSONReport is the envelope of every report exported as JSON, Report holds
+// the ComparisonReport, MultiComparisonReport, SummaryReport or trend Report
+// named by Kind
+type JSONReport struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Kind          string `json:"kind"`
+	Report        any    `json:"report"`
+}
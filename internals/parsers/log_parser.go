@@ -11,6 +11,46 @@ import (
 	"strings"
 )
 
+// levelPattern matches the line starting the log of a level
+var levelPattern = regexp.MustCompile(`\[server\]\[info\] Running client on level file: (.+)$`)
+
+// LevelLog is the part of a client log written while running one level
+type LevelLog struct {
+	LevelName string
+	Lines     []string
+}
+
+// SplitLogByLevel splits a client log into the logs of every level, in the
+// order they were run. With repeated runs a level has one log per run
+func SplitLogByLevel(logFilePath string) ([]LevelLog, error) {
+	file, err := os.Open(logFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening log file: %w", err)
+	}
+	defer file.Close()
+
+	var logs []LevelLog
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if levelMatch := levelPattern.FindStringSubmatch(line); levelMatch != nil {
+			logs = append(logs, LevelLog{LevelName: levelName(levelMatch[1])})
+		}
+		if len(logs) > 0 {
+			logs[len(logs)-1].Lines = append(logs[len(logs)-1].Lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading log file: %w", err)
+	}
+	return logs, nil
+}
+
+// levelName returns the name of a level from the path of its file
+func levelName(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+}
+
 // ParseLogToCSV parses a log file and writes the extracted metrics to a CSV file.
 func ParseLogToCSV(logFilePath string, outputFilePath string) error {
 	file, err := os.Open(logFilePath)
@@ -19,7 +59,6 @@ func ParseLogToCSV(logFilePath string, outputFilePath string) error {
 	}
 	defer file.Close()
 
-	levelPattern := regexp.MustCompile(`\[server\]\[info\] Running client on level file: (.+)$`)
 	solvedPattern := regexp.MustCompile(`\[server\]\[info\] Level solved: (Yes|No)`)
 	actionsPattern := regexp.MustCompile(`\[server\]\[info\] Actions used: (\d{1,3}(?:,\d{3})*)`)
 	timePattern := regexp.MustCompile(`\[server\]\[info\] Time to solve: (\d{1,3}(?:,\d{3})*(?:\.\d+)?)`)
@@ -39,8 +78,7 @@ func ParseLogToCSV(logFilePath string, outputFilePath string) error {
 			if currentLevel != nil {
 				logs = append(logs, *currentLevel)
 			}
-			levelName := strings.TrimSuffix(filepath.Base(levelMatch[1]), filepath.Ext(levelMatch[1]))
-			currentLevel = &models.LevelMetrics{LevelName: levelName}
+			currentLevel = &models.LevelMetrics{LevelName: levelName(levelMatch[1])}
 		}
 
 		if currentLevel != nil {
@@ -0,0 +1,483 @@
+// Package plots renders benchmark results as static figures with gonum/plot,
+// for reports and papers where the interactive HTML reports do not fit
+package plots
+
+import (
+	"fmt"
+	"image/color"
+	"masbench/internals/summarizer"
+	"masbench/internals/utils"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-gota/gota/dataframe"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/text"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+)
+
+// Kinds of figures
+const (
+	KindBar     = "bar"
+	KindScatter = "scatter"
+	KindCactus  = "cactus"
+	KindBox     = "box"
+	KindProfile = "profile"
+)
+
+// Kinds are the supported kinds of figures
+var Kinds = []string{KindBar, KindScatter, KindCactus, KindBox, KindProfile}
+
+// Extensions are the supported output formats
+var Extensions = []string{".svg", ".png", ".pdf"}
+
+// palette matches the colors of the HTML reports, the n-th benchmark of a
+// figure always gets the n-th color so that figures of the same benchmarks match
+var palette = []color.Color{
+	color.RGBA{R: 59, G: 130, B: 246, A: 255},  // blue
+	color.RGBA{R: 249, G: 115, B: 22, A: 255},  // orange
+	color.RGBA{R: 34, G: 197, B: 94, A: 255},   // green
+	color.RGBA{R: 168, G: 85, B: 247, A: 255},  // purple
+	color.RGBA{R: 239, G: 68, B: 68, A: 255},   // red
+	color.RGBA{R: 20, G: 184, B: 166, A: 255},  // teal
+	color.RGBA{R: 236, G: 72, B: 153, A: 255},  // pink
+	color.RGBA{R: 107, G: 114, B: 128, A: 255}, // gray
+}
+
+// Series holds the value of a metric on every level a benchmark solved
+type Series struct {
+	Name     string
+	Values   map[string]float64
+	Levels   []string        // every level run by the benchmark, solved or not
+	Unsolved map[string]bool // levels run but not solved by the benchmark
+}
+
+// Options of a figure
+type Options struct {
+	Kind   string
+	Metric string // CSV column of the plotted metric
+	Log    bool   // logarithmic metric axis
+}
+
+// Color returns the color of the i-th benchmark of a figure
+func Color(i int) color.Color {
+	return palette[i%len(palette)]
+}
+
+// LoadSeries extracts the values of a metric from a results CSV. Only solved
+// levels are kept
+func LoadSeries(name string, df dataframe.DataFrame, metric string) Series {
+	series := Series{Name: name, Values: make(map[string]float64), Unsolved: make(map[string]bool)}
+
+	for level, rows := range utils.ToRowsMap(df) {
+		series.Levels = append(series.Levels, level)
+		runs := utils.ResolveRuns(rows)
+		if !runs.Solved() {
+			series.Unsolved[level] = true
+			continue
+		}
+		if value, ok := runs.Median(metric); ok {
+			series.Values[level] = value
+		}
+	}
+
+	return series
+}
+
+// Render draws a figure of the series and saves it to path, the format is
+// chosen from the extension
+func Render(series []Series, opts Options, path string) error {
+	if err := checkExtension(path); err != nil {
+		return err
+	}
+	if len(series) == 0 {
+		return fmt.Errorf("no benchmark to plot")
+	}
+	if opts.Log {
+		series = positiveOnly(series)
+	}
+
+	var p *plot.Plot
+	var err error
+	width := 16 * vg.Centimeter
+	switch opts.Kind {
+	case KindBar:
+		p, err = barPlot(series, opts)
+		// Keep the bars readable when there are many levels
+		if w := vg.Length(len(levels(series))*len(series)) * 0.3 * vg.Centimeter; w > width {
+			width = w
+		}
+	case KindScatter:
+		p, err = scatterPlot(series, opts)
+	case KindCactus:
+		p, err = cactusPlot(series, opts)
+	case KindBox:
+		p, err = boxPlot(series, opts)
+	case KindProfile:
+		p, err = profilePlot(series, opts)
+	default:
+		return fmt.Errorf("unknown kind %q, expected one of %s", opts.Kind, strings.Join(Kinds, ", "))
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := p.Save(width, 10*vg.Centimeter, path); err != nil {
+		return fmt.Errorf("failed to save plot: %w", err)
+	}
+	return nil
+}
+
+// barPlot draws grouped bars with the value of every benchmark per level
+func barPlot(series []Series, opts Options) (*plot.Plot, error) {
+	if opts.Log {
+		return nil, fmt.Errorf("log scale is not supported for bar plots, bars start at zero")
+	}
+
+	p := newPlot(fmt.Sprintf("%s per level", opts.Metric))
+	p.Y.Label.Text = axisLabel(opts)
+	p.Legend.Top = true
+
+	names := levels(series)
+	barWidth := vg.Points(24 / float64(len(series)))
+	for i, s := range series {
+		values := make(plotter.Values, len(names))
+		for j, level := range names {
+			values[j] = s.Values[level]
+		}
+
+		bars, err := plotter.NewBarChart(values, barWidth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create bars of %s: %w", s.Name, err)
+		}
+		bars.Color = Color(i)
+		bars.LineStyle.Width = 0
+		bars.Offset = barWidth * vg.Length(2*i-len(series)+1) / 2
+
+		p.Add(bars)
+		p.Legend.Add(s.Name, bars)
+
+		labels, err := missingBarLabels(s, names, bars.Offset, Color(i))
+		if err != nil {
+			return nil, err
+		}
+		if labels != nil {
+			p.Add(labels)
+		}
+	}
+	p.NominalX(names...)
+
+	return p, nil
+}
+
+// missingBarLabels labels the levels where a benchmark has no bar, at the
+// position of its bar, so that they are not mistaken for values of zero
+func missingBarLabels(s Series, names []string, offset vg.Length, c color.Color) (*plotter.Labels, error) {
+	var missing plotter.XYLabels
+	for j, level := range names {
+		if _, ok := s.Values[level]; ok {
+			continue
+		}
+		label := "n/a"
+		if s.Unsolved[level] {
+			label = "unsolved"
+		}
+		missing.XYs = append(missing.XYs, plotter.XY{X: float64(j)})
+		missing.Labels = append(missing.Labels, label)
+	}
+	if len(missing.Labels) == 0 {
+		return nil, nil
+	}
+
+	labels, err := plotter.NewLabels(missing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to label the missing bars of %s: %w", s.Name, err)
+	}
+	for i := range labels.TextStyle {
+		style := &labels.TextStyle[i]
+		style.Color = c
+		style.Font.Size = vg.Points(7)
+		style.Rotation = math.Pi / 2
+		style.XAlign = text.XLeft
+		style.YAlign = text.YCenter
+	}
+	labels.Offset = vg.Point{X: offset, Y: vg.Points(2)}
+	return labels, nil
+}
+
+// scatterPlot draws every benchmark against the first one, one point per
+// level solved by both. Points below the diagonal are levels where the
+// benchmark has a lower value than the first one
+func scatterPlot(series []Series, opts Options) (*plot.Plot, error) {
+	if len(series) < 2 {
+		return nil, fmt.Errorf("a scatter plot needs at least two benchmarks")
+	}
+
+	reference := series[0]
+	p := newPlot(fmt.Sprintf("%s per level against %s", opts.Metric, reference.Name))
+	p.X.Label.Text = fmt.Sprintf("%s of %s", axisLabel(opts), reference.Name)
+	p.Y.Label.Text = axisLabel(opts)
+	p.Legend.Top = true
+	p.Legend.Left = true
+	if opts.Log {
+		logScale(&p.X)
+	}
+
+	low, high := math.Inf(1), math.Inf(-1)
+	for i, s := range series[1:] {
+		var points plotter.XYs
+		for level, x := range reference.Values {
+			y, ok := s.Values[level]
+			if !ok {
+				continue
+			}
+			points = append(points, plotter.XY{X: x, Y: y})
+			low, high = min(low, x, y), max(high, x, y)
+		}
+		if len(points) == 0 {
+			continue
+		}
+
+		scatter, err := plotter.NewScatter(points)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create points of %s: %w", s.Name, err)
+		}
+		scatter.GlyphStyle.Color = Color(i + 1)
+		scatter.GlyphStyle.Radius = vg.Points(3)
+		scatter.GlyphStyle.Shape = draw.CircleGlyph{}
+
+		p.Add(scatter)
+		p.Legend.Add(s.Name, scatter)
+	}
+	if low > high {
+		return nil, fmt.Errorf("no level is solved by %s and another benchmark", reference.Name)
+	}
+
+	diagonal, err := plotter.NewLine(plotter.XYs{{X: low, Y: low}, {X: high, Y: high}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create diagonal: %w", err)
+	}
+	diagonal.LineStyle.Color = color.Gray{Y: 128}
+	diagonal.LineStyle.Dashes = []vg.Length{vg.Points(4), vg.Points(4)}
+	p.Add(diagonal)
+
+	if opts.Log {
+		logScale(&p.Y)
+	}
+
+	return p, nil
+}
+
+// cactusPlot draws, for every benchmark, the number of levels solved within
+// a budget of the metric, the higher and further left the better
+func cactusPlot(series []Series, opts Options) (*plot.Plot, error) {
+	p := newPlot(fmt.Sprintf("Cactus plot of %s", opts.Metric))
+	p.X.Label.Text = axisLabel(opts) + " budget"
+	p.Y.Label.Text = "Levels solved"
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	for i, s := range series {
+		cactus := summarizer.ComputeCactus(s.Name, s.Values)
+		if len(cactus.Points) == 0 {
+			continue
+		}
+
+		points := make(plotter.XYs, len(cactus.Points))
+		for j, point := range cactus.Points {
+			points[j] = plotter.XY{X: point.Budget, Y: float64(point.Solved)}
+		}
+
+		line, err := plotter.NewLine(points)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create line of %s: %w", s.Name, err)
+		}
+		line.StepStyle = plotter.PostStep
+		line.LineStyle.Color = Color(i)
+		line.LineStyle.Width = vg.Points(1.5)
+
+		marks, err := plotter.NewScatter(points)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create points of %s: %w", s.Name, err)
+		}
+		marks.GlyphStyle.Color = Color(i)
+		marks.GlyphStyle.Radius = vg.Points(2)
+		marks.GlyphStyle.Shape = draw.CircleGlyph{}
+
+		p.Add(line, marks)
+		p.Legend.Add(s.Name, line, marks)
+	}
+
+	if opts.Log {
+		logScale(&p.X)
+	}
+
+	return p, nil
+}
+
+// profilePlot draws the Dolan-Moré performance profile of every benchmark,
+// the fraction of levels solved within a factor tau of the best benchmark
+func profilePlot(series []Series, opts Options) (*plot.Plot, error) {
+	p := newPlot(fmt.Sprintf("Performance profile of %s", opts.Metric))
+	p.X.Label.Text = "τ (ratio to the best)"
+	p.Y.Label.Text = "Fraction of levels"
+	p.Y.Min, p.Y.Max = 0, 1
+
+	// Ratios span several orders of magnitude, τ is always on a log scale
+	logScale(&p.X)
+
+	names := make([]string, len(series))
+	values := make([]map[string]float64, len(series))
+	for i, s := range series {
+		names[i] = s.Name
+		values[i] = s.Values
+	}
+	profiles := summarizer.ComputePerformanceProfiles(names, values, len(allLevels(series)))
+
+	maxTau := 1.0
+	for _, profile := range profiles {
+		for _, point := range profile.Points {
+			maxTau = max(maxTau, point.Tau)
+		}
+	}
+	maxTau *= 1.1
+
+	for i, profile := range profiles {
+		points := make(plotter.XYs, 0, len(profile.Points)+1)
+		for _, point := range profile.Points {
+			points = append(points, plotter.XY{X: point.Tau, Y: point.Fraction})
+		}
+		points = append(points, plotter.XY{X: maxTau, Y: profile.SolvedFraction})
+
+		line, err := plotter.NewLine(points)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create line of %s: %w", profile.Benchmark, err)
+		}
+		line.StepStyle = plotter.PostStep
+		line.LineStyle.Color = Color(i)
+		line.LineStyle.Width = vg.Points(1.5)
+
+		p.Add(line)
+		p.Legend.Add(profile.Benchmark, line)
+	}
+
+	return p, nil
+}
+
+// boxPlot draws the distribution of the values of every benchmark
+func boxPlot(series []Series, opts Options) (*plot.Plot, error) {
+	p := newPlot(fmt.Sprintf("Distribution of %s over solved levels", opts.Metric))
+	p.Y.Label.Text = axisLabel(opts)
+
+	names := make([]string, len(series))
+	for i, s := range series {
+		names[i] = s.Name
+		values := sortedValues(s)
+		if len(values) == 0 {
+			continue
+		}
+
+		box, err := plotter.NewBoxPlot(vg.Points(30), float64(i), plotter.Values(values))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create box of %s: %w", s.Name, err)
+		}
+		box.FillColor = Color(i)
+
+		p.Add(box)
+	}
+	p.NominalX(names...)
+
+	if opts.Log {
+		logScale(&p.Y)
+	}
+
+	return p, nil
+}
+
+func newPlot(title string) *plot.Plot {
+	p := plot.New()
+	p.Title.Text = title
+	p.Add(plotter.NewGrid())
+	return p
+}
+
+// logScale switches an axis to a logarithmic scale
+func logScale(axis *plot.Axis) {
+	axis.Scale = plot.LogScale{}
+	axis.Tick.Marker = plot.LogTicks{Prec: -1}
+}
+
+func axisLabel(opts Options) string {
+	if opts.Log {
+		return opts.Metric + " (log)"
+	}
+	return opts.Metric
+}
+
+// levels returns the sorted levels solved by any of the series
+func levels(series []Series) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, s := range series {
+		for level := range s.Values {
+			if !seen[level] {
+				seen[level] = true
+				names = append(names, level)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// allLevels returns the levels run by any of the series
+func allLevels(series []Series) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, s := range series {
+		for _, level := range s.Levels {
+			if !seen[level] {
+				seen[level] = true
+				names = append(names, level)
+			}
+		}
+	}
+	return names
+}
+
+func sortedValues(s Series) []float64 {
+	values := make([]float64, 0, len(s.Values))
+	for _, value := range s.Values {
+		values = append(values, value)
+	}
+	sort.Float64s(values)
+	return values
+}
+
+// positiveOnly drops the values a logarithmic axis cannot show
+func positiveOnly(series []Series) []Series {
+	filtered := make([]Series, len(series))
+	for i, s := range series {
+		filtered[i] = Series{Name: s.Name, Values: make(map[string]float64), Levels: s.Levels, Unsolved: s.Unsolved}
+		for level, value := range s.Values {
+			if value > 0 {
+				filtered[i].Values[level] = value
+			}
+		}
+	}
+	return filtered
+}
+
+func checkExtension(path string) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, supported := range Extensions {
+		if ext == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported output format %q, expected one of %s", ext, strings.Join(Extensions, ", "))
+}
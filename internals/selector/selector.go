@@ -0,0 +1,233 @@
+// Package selector resolves the benchmark selectors given on the command
+// line, such as globs, tags, latest~1 or since:2026-09-01, to benchmark names
+package selector
+
+import (
+	"fmt"
+	"masbench/internals/index"
+	"masbench/internals/models"
+	"masbench/internals/utils"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Prefixes of the selectors that are not globs or names
+const (
+	TagPrefix   = "tag:"
+	SincePrefix = "since:"
+	UntilPrefix = "until:"
+	Latest      = "latest"
+)
+
+// dateLayout is the layout of the dates of since: and until:
+const dateLayout = "2006-01-02"
+
+// Selector resolves selectors against the benchmarks of a benchmark folder
+type Selector struct {
+	folder   string
+	names    []string // sorted
+	dated    []string // sorted, the benchmarks with results
+	metadata map[string]models.RunMetadata
+}
+
+// New lists the benchmarks of the benchmark folder, the ones with results
+func New(folder string) (*Selector, error) {
+	names, err := index.BenchmarkNames(folder)
+	if err != nil {
+		return nil, err
+	}
+	return &Selector{folder: folder, names: names, dated: names, metadata: make(map[string]models.RunMetadata)}, nil
+}
+
+// NewAll lists every benchmark of the benchmark folder, including the ones
+// without results such as interrupted runs. Those are only selected by name
+// or glob, they have no tags and no date
+func NewAll(folder string) (*Selector, error) {
+	names, err := index.BenchmarkFolders(folder)
+	if err != nil {
+		return nil, err
+	}
+	dated, err := index.BenchmarkNames(folder)
+	if err != nil {
+		return nil, err
+	}
+	return &Selector{folder: folder, names: names, dated: dated, metadata: make(map[string]models.RunMetadata)}, nil
+}
+
+// Resolve returns the benchmarks selected by any of the selectors, in the
+// order of the selectors and without duplicates. A selector is one of:
+//
+//   - the name of a benchmark
+//   - a glob on the names, such as astar-*
+//   - tag:<tag>, the benchmarks with that tag
+//   - latest, the last benchmark run, latest~N the one run N before it
+//   - since:<date> and until:<date>, the benchmarks run on or after, or on
+//     or before, a date such as 2026-09-01, since:<from>..<to> a range
+//
+// Tags are only selected with tag:, a name that is not a benchmark is an
+// error. Selectors matching several benchmarks list them by name, a selector
+// matching none is an error
+func (s *Selector) Resolve(selectors []string) ([]string, error) {
+	var selected []string
+	for _, selector := range selectors {
+		matched, err := s.match(selector)
+		if err != nil {
+			return nil, err
+		}
+		if len(matched) == 0 {
+			return nil, fmt.Errorf("no benchmark matches %s", selector)
+		}
+		for _, name := range matched {
+			if !slices.Contains(selected, name) {
+				selected = append(selected, name)
+			}
+		}
+	}
+	return selected, nil
+}
+
+// ResolveOne returns the only benchmark selected by a selector
+func (s *Selector) ResolveOne(selector string) (string, error) {
+	names, err := s.Resolve([]string{selector})
+	if err != nil {
+		return "", err
+	}
+	if len(names) > 1 {
+		return "", fmt.Errorf("%s matches %d benchmarks (%s), expected one", selector, len(names), strings.Join(names, ", "))
+	}
+	return names[0], nil
+}
+
+func (s *Selector) match(selector string) ([]string, error) {
+	switch {
+	case slices.Contains(s.names, selector):
+		return []string{selector}, nil
+	case strings.HasPrefix(selector, TagPrefix):
+		return s.matchTag(strings.TrimPrefix(selector, TagPrefix))
+	case strings.HasPrefix(selector, SincePrefix) || strings.HasPrefix(selector, UntilPrefix):
+		return s.matchDate(selector)
+	case selector == Latest || strings.HasPrefix(selector, Latest+"~"):
+		return s.matchLatest(selector)
+	case IsGlob(selector):
+		return s.matchGlob(selector)
+	default:
+		return nil, fmt.Errorf("no benchmark called %s, use %s%s to select by tag", selector, TagPrefix, selector)
+	}
+}
+
+func (s *Selector) matchGlob(pattern string) ([]string, error) {
+	var matched []string
+	for _, name := range s.names {
+		ok, err := filepath.Match(pattern, name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if ok {
+			matched = append(matched, name)
+		}
+	}
+	return matched, nil
+}
+
+func (s *Selector) matchTag(tag string) ([]string, error) {
+	var matched []string
+	for _, name := range s.dated {
+		meta, err := s.loadMetadata(name)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(meta.Tags, tag) {
+			matched = append(matched, name)
+		}
+	}
+	return matched, nil
+}
+
+// matchLatest selects a benchmark by how recently it was run, latest~0 is
+// latest and latest~1 the benchmark run before it
+func (s *Selector) matchLatest(selector string) ([]string, error) {
+	offset := 0
+	if rest, ok := strings.CutPrefix(selector, Latest+"~"); ok {
+		var err error
+		if offset, err = strconv.Atoi(rest); err != nil || offset < 0 {
+			return nil, fmt.Errorf("invalid selector %q, expected latest~<N>", selector)
+		}
+	}
+
+	started := make(map[string]time.Time, len(s.dated))
+	for _, name := range s.dated {
+		meta, err := s.loadMetadata(name)
+		if err != nil {
+			return nil, err
+		}
+		started[name] = meta.StartedAt
+	}
+	names := slices.Clone(s.dated)
+	slices.SortStableFunc(names, func(a, b string) int {
+		return started[b].Compare(started[a])
+	})
+
+	if offset >= len(names) {
+		return nil, nil
+	}
+	return []string{names[offset]}, nil
+}
+
+// matchDate selects the benchmarks run within a range of days, in local
+// time. since:<from>[..<to>] and until:<to> include both ends
+func (s *Selector) matchDate(selector string) ([]string, error) {
+	var from, to time.Time
+	var err error
+	if value, ok := strings.CutPrefix(selector, UntilPrefix); ok {
+		to, err = parseDate(value)
+	} else {
+		value = strings.TrimPrefix(selector, SincePrefix)
+		first, last, isRange := strings.Cut(value, "..")
+		if from, err = parseDate(first); err == nil && isRange {
+			to, err = parseDate(last)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid date in %q, expected YYYY-MM-DD: %w", selector, err)
+	}
+
+	var matched []string
+	for _, name := range s.dated {
+		meta, err := s.loadMetadata(name)
+		if err != nil {
+			return nil, err
+		}
+		if !from.IsZero() && meta.StartedAt.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !meta.StartedAt.Before(to.AddDate(0, 0, 1)) {
+			continue
+		}
+		matched = append(matched, name)
+	}
+	return matched, nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	return time.ParseInLocation(dateLayout, value, time.Local)
+}
+
+func (s *Selector) loadMetadata(name string) (models.RunMetadata, error) {
+	if meta, ok := s.metadata[name]; ok {
+		return meta, nil
+	}
+	meta, err := utils.LoadMetadata(s.folder, name)
+	if err != nil {
+		return models.RunMetadata{}, err
+	}
+	s.metadata[name] = meta
+	return meta, nil
+}
+
+// IsGlob reports whether a selector uses glob syntax
+func IsGlob(selector string) bool {
+	return strings.ContainsAny(selector, "*?[")
+}
@@ -0,0 +1,346 @@
+// Package server serves a dashboard over the benchmark folder, comparing and
+// summarizing benchmarks on the fly
+package server
+
+import (
+	"fmt"
+	"html/template"
+	"masbench/internals/assets"
+	"masbench/internals/comparator"
+	"masbench/internals/index"
+	"masbench/internals/parsers"
+	"masbench/internals/summarizer"
+	"masbench/internals/utils"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-gota/gota/dataframe"
+)
+
+// Actions change the benchmark folder, they are provided by the commands
+// doing the same so that the dashboard behaves like the command line
+type Actions struct {
+	Remove func(name string) error
+	Rename func(oldName, newName string) error
+}
+
+// Server is the dashboard over a benchmark folder
+type Server struct {
+	folder    string
+	options   comparator.Options
+	actions   Actions
+	templates *template.Template
+	mu        sync.Mutex // held by the actions, one change at a time
+}
+
+// New returns the dashboard over the benchmark folder
+func New(folder string, options comparator.Options, actions Actions) (*Server, error) {
+	templates, err := template.New("dashboard").Funcs(template.FuncMap{
+		"join":   strings.Join,
+		"styles": assets.Styles,
+	}).Parse(dashboardTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	if _, err := templates.New("logs").Parse(logsTemplate); err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	return &Server{folder: folder, options: options, actions: actions, templates: templates}, nil
+}
+
+// Handler returns the HTTP handler of the dashboard
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleDashboard)
+	mux.HandleFunc("GET /compare", s.handleCompare)
+	mux.HandleFunc("GET /summary", s.handleSummary)
+	mux.HandleFunc("GET /benchmarks/{name}/logs", s.handleLogs)
+	mux.HandleFunc("GET /benchmarks/{name}/logs/{level}", s.handleLevelLog)
+	mux.HandleFunc("POST /benchmarks/{name}/delete", sameOrigin(s.handleDelete))
+	mux.HandleFunc("POST /benchmarks/{name}/rename", sameOrigin(s.handleRename))
+	return mux
+}
+
+type dashboardData struct {
+	Benchmarks []index.Benchmark
+	Message    string
+	Error      string
+}
+
+func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	names, err := index.BenchmarkNames(s.folder)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := dashboardData{
+		Message: r.URL.Query().Get("message"),
+		Error:   r.URL.Query().Get("error"),
+	}
+	for _, name := range names {
+		benchmark, err := index.LoadBenchmark(s.folder, name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		data.Benchmarks = append(data.Benchmarks, benchmark)
+	}
+	slices.SortStableFunc(data.Benchmarks, func(a, b index.Benchmark) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+
+	s.render(w, "dashboard", data)
+}
+
+// handleCompare compares the two selected benchmarks, or every selected
+// benchmark against the baseline
+func (s *Server) handleCompare(w http.ResponseWriter, r *http.Request) {
+	names := r.URL.Query()["b"]
+	baseline := r.URL.Query().Get("baseline")
+	if baseline != "" {
+		names = slices.DeleteFunc(names, func(name string) bool { return name == baseline })
+	}
+
+	if baseline == "" {
+		if len(names) != 2 {
+			http.Error(w, "select exactly two benchmarks to compare, or a baseline", http.StatusBadRequest)
+			return
+		}
+		dataframes, ok := s.loadResults(w, names)
+		if !ok {
+			return
+		}
+		report := comparator.PrepareComparisonData(dataframes[0], dataframes[1], names[0], names[1], s.options)
+		s.writeHTML(w, func() error { return comparator.WriteHTML(w, report) })
+		return
+	}
+
+	if len(names) == 0 {
+		http.Error(w, "select at least one benchmark to compare against the baseline", http.StatusBadRequest)
+		return
+	}
+	dataframes, ok := s.loadResults(w, append([]string{baseline}, names...))
+	if !ok {
+		return
+	}
+	report := comparator.PrepareMultiComparisonData(dataframes[0], baseline, dataframes[1:], names, s.options)
+	s.writeHTML(w, func() error { return comparator.WriteMultiHTML(w, report) })
+}
+
+func (s *Server) handleSummary(w http.ResponseWriter, r *http.Request) {
+	names := r.URL.Query()["b"]
+	if len(names) == 0 {
+		http.Error(w, "select at least one benchmark to summarize", http.StatusBadRequest)
+		return
+	}
+
+	paths := make(map[string]string, len(names))
+	for _, name := range names {
+		if !s.exists(name) {
+			http.Error(w, fmt.Sprintf("no benchmark called %s", name), http.StatusNotFound)
+			return
+		}
+		paths[name] = s.resultsPath(name)
+	}
+
+	report, err := summarizer.PrepareSummaryData(paths)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.writeHTML(w, func() error { return summarizer.WriteHTML(w, report) })
+}
+
+type logsData struct {
+	Benchmark string
+	Levels    []parsers.LevelLog
+}
+
+// handleLogs lists the levels of the client log of a benchmark
+func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	logs, ok := s.loadLogs(w, name)
+	if !ok {
+		return
+	}
+	s.render(w, "logs", logsData{Benchmark: name, Levels: logs})
+}
+
+// handleLevelLog shows the raw client log of a level, with repeated runs
+// the logs of every run follow each other
+func (s *Server) handleLevelLog(w http.ResponseWriter, r *http.Request) {
+	name, level := r.PathValue("name"), r.PathValue("level")
+	logs, ok := s.loadLogs(w, name)
+	if !ok {
+		return
+	}
+
+	found := false
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	for _, log := range logs {
+		if log.LevelName != level {
+			continue
+		}
+		found = true
+		fmt.Fprintln(w, strings.Join(log.Lines, "\n"))
+		fmt.Fprintln(w)
+	}
+	if !found {
+		http.Error(w, fmt.Sprintf("no log of level %s in %s", level, name), http.StatusNotFound)
+	}
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.actions.Remove(name); err != nil {
+		redirect(w, r, "error", err.Error())
+		return
+	}
+	redirect(w, r, "message", fmt.Sprintf("Removed %s", name))
+}
+
+func (s *Server) handleRename(w http.ResponseWriter, r *http.Request) {
+	name, newName := r.PathValue("name"), strings.TrimSpace(r.FormValue("name"))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.actions.Rename(name, newName); err != nil {
+		redirect(w, r, "error", err.Error())
+		return
+	}
+	redirect(w, r, "message", fmt.Sprintf("Renamed %s to %s", name, newName))
+}
+
+// sameOrigin rejects requests sent by other web sites, so that a page open in
+// the browser cannot delete or rename benchmarks behind the user's back.
+// Browsers tell where a request comes from with Sec-Fetch-Site, or Origin
+// for older ones. Requests without either do not come from a browser, such
+// as curl, and are allowed
+func sameOrigin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		site := r.Header.Get("Sec-Fetch-Site")
+		origin := r.Header.Get("Origin")
+		switch {
+		case site != "":
+			if site != "same-origin" && site != "none" {
+				http.Error(w, "cross-origin request rejected", http.StatusForbidden)
+				return
+			}
+		case origin != "":
+			u, err := url.Parse(origin)
+			if err != nil || u.Host != r.Host {
+				http.Error(w, "cross-origin request rejected", http.StatusForbidden)
+				return
+			}
+		}
+		next(w, r)
+	}
+}
+
+// knownHost rejects requests whose Host is not the address the dashboard
+// listens on. A web site resolving its own name to the dashboard, DNS
+// rebinding, would otherwise pass as same-origin. The dashboard answers to
+// IP addresses, localhost, the name of the machine and the --host name
+func knownHost(addr string, next http.Handler) http.Handler {
+	listenHost, listenPort, _ := net.SplitHostPort(addr)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, port, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host, port = strings.Trim(r.Host, "[]"), "80"
+		}
+		if port != listenPort || !allowedHost(strings.TrimSuffix(host, "."), listenHost) {
+			http.Error(w, "unknown host "+r.Host, http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func allowedHost(host, listenHost string) bool {
+	if net.ParseIP(host) != nil || strings.EqualFold(host, "localhost") || strings.EqualFold(host, listenHost) {
+		return true
+	}
+	name, err := os.Hostname()
+	return err == nil && strings.EqualFold(host, name)
+}
+
+// redirect goes back to the dashboard with a message
+func redirect(w http.ResponseWriter, r *http.Request, key, message string) {
+	http.Redirect(w, r, "/?"+url.Values{key: {message}}.Encode(), http.StatusSeeOther)
+}
+
+// exists reports whether name is a benchmark with results, which also keeps
+// requests from reaching files outside the benchmark folder
+func (s *Server) exists(name string) bool {
+	names, err := index.BenchmarkNames(s.folder)
+	return err == nil && slices.Contains(names, name)
+}
+
+func (s *Server) resultsPath(name string) string {
+	return filepath.Join(s.folder, name, fmt.Sprintf("%s_results.csv", name))
+}
+
+// loadResults loads the results of the benchmarks, writing an error
+// response if one of them cannot be loaded
+func (s *Server) loadResults(w http.ResponseWriter, names []string) ([]dataframe.DataFrame, bool) {
+	dataframes := make([]dataframe.DataFrame, len(names))
+	for i, name := range names {
+		if !s.exists(name) {
+			http.Error(w, fmt.Sprintf("no benchmark called %s", name), http.StatusNotFound)
+			return nil, false
+		}
+		df, err := utils.LoadCSV(s.resultsPath(name))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return nil, false
+		}
+		dataframes[i] = df
+	}
+	return dataframes, true
+}
+
+func (s *Server) loadLogs(w http.ResponseWriter, name string) ([]parsers.LevelLog, bool) {
+	if !s.exists(name) {
+		http.Error(w, fmt.Sprintf("no benchmark called %s", name), http.StatusNotFound)
+		return nil, false
+	}
+	logs, err := parsers.SplitLogByLevel(filepath.Join(s.folder, name, "logs", fmt.Sprintf("%s_client.clog", name)))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return nil, false
+	}
+	return logs, true
+}
+
+func (s *Server) render(w http.ResponseWriter, name string, data any) {
+	s.writeHTML(w, func() error { return s.templates.ExecuteTemplate(w, name, data) })
+}
+
+// writeHTML writes a page, reporting the error of a page that failed before
+// anything was written
+func (s *Server) writeHTML(w http.ResponseWriter, write func() error) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := write(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// ListenAndServe serves the dashboard on addr until the server fails
+func (s *Server) ListenAndServe(addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           knownHost(addr, s.Handler()),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return server.ListenAndServe()
+}
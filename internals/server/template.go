@@ -0,0 +1,171 @@
+package server
+
+const dashboardTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Masbench Dashboard</title>
+    {{styles}}
+    <style>
+        body {
+            color: #000000;
+            background-color: #f9fafb;
+        }
+        .badge {
+            display: inline-block;
+            padding: 0.25rem 0.75rem;
+            border-radius: 9999px;
+            font-size: 0.75rem;
+            font-weight: 600;
+            background-color: #f3e8ff;
+            color: #6b21a8;
+        }
+    </style>
+</head>
+<body class="bg-gray-50">
+    <!-- Header -->
+    <div class="bg-white shadow-sm border-b border-gray-200">
+        <div class="max-w-7xl mx-auto px-4 py-6">
+            <div class="flex justify-between items-center">
+                <div>
+                    <h1 class="text-3xl font-bold text-gray-900">🖥️ Masbench Dashboard</h1>
+                    <p class="mt-2 text-sm text-gray-600">{{len .Benchmarks}} benchmarks, select some to compare or summarize them</p>
+                </div>
+                <input
+                    type="text"
+                    id="searchInput"
+                    placeholder="🔍 Search..."
+                    class="px-4 py-2 border border-gray-300 rounded-lg focus:outline-none focus:ring-2 focus:ring-blue-500"
+                    onkeyup="filterRows()"
+                >
+            </div>
+        </div>
+    </div>
+
+    <div class="max-w-7xl mx-auto px-4 py-8">
+        {{if .Message}}
+        <div class="mb-4 p-4 rounded-lg bg-green-50 border border-green-200 text-sm text-green-800">{{.Message}}</div>
+        {{end}}
+        {{if .Error}}
+        <div class="mb-4 p-4 rounded-lg bg-red-50 border border-red-200 text-sm text-red-800">{{.Error}}</div>
+        {{end}}
+
+        <div class="bg-white rounded-lg shadow border border-gray-200">
+            <div class="p-6 border-b border-gray-200 flex justify-between items-center">
+                <h2 class="text-2xl font-bold text-gray-900">🏃 Benchmarks</h2>
+                <div class="flex items-center gap-2">
+                    <select form="selection" name="baseline" class="px-3 py-2 border border-gray-300 rounded-lg text-sm">
+                        <option value="">No baseline</option>
+                        {{range .Benchmarks}}<option value="{{.Name}}">{{.Name}}</option>{{end}}
+                    </select>
+                    <button form="selection" type="submit" formaction="/compare" class="px-4 py-2 rounded-lg bg-blue-600 text-white text-sm font-medium hover:bg-blue-700">Compare</button>
+                    <button form="selection" type="submit" formaction="/summary" class="px-4 py-2 rounded-lg bg-green-600 text-white text-sm font-medium hover:bg-green-700">Summarize</button>
+                </div>
+            </div>
+            <form id="selection" method="get" action="/compare" target="_blank"></form>
+            <div class="overflow-x-auto">
+                <table class="min-w-full divide-y divide-gray-200">
+                    <thead class="bg-gray-50">
+                        <tr>
+                            <th class="px-4 py-2"></th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Benchmark</th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Description</th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Date</th>
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Solved</th>
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Time (solved)</th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Actions</th>
+                        </tr>
+                    </thead>
+                    <tbody class="bg-white divide-y divide-gray-200">
+                        {{range .Benchmarks}}
+                        <tr class="searchable">
+                            <td class="px-4 py-2"><input form="selection" type="checkbox" name="b" value="{{.Name}}"></td>
+                            <td class="px-4 py-2 text-sm font-medium text-gray-900">
+                                {{.Name}}
+                                {{range .Tags}}<span class="badge ml-1">{{.}}</span>{{end}}
+                            </td>
+                            <td class="px-4 py-2 text-sm text-gray-600">{{.Description}}</td>
+                            <td class="px-4 py-2 text-sm text-gray-600 whitespace-nowrap">{{.StartedAt.Format "2006-01-02 15:04"}}</td>
+                            <td class="px-4 py-2 text-sm text-right text-gray-900">{{.LevelsSolved}} / {{.LevelsTotal}}</td>
+                            <td class="px-4 py-2 text-sm text-right text-gray-900">{{printf "%.2fs" .TotalTime}}</td>
+                            <td class="px-4 py-2 text-sm whitespace-nowrap">
+                                <div class="flex items-center gap-2">
+                                    <a class="text-blue-600 hover:underline" href="/benchmarks/{{.Name}}/logs">Logs</a>
+                                    <form method="post" action="/benchmarks/{{.Name}}/rename" class="flex items-center gap-1">
+                                        <input type="text" name="name" placeholder="New name" required class="px-2 py-1 border border-gray-300 rounded text-sm">
+                                        <button type="submit" class="text-blue-600 hover:underline">Rename</button>
+                                    </form>
+                                    <form method="post" action="/benchmarks/{{.Name}}/delete" onsubmit="return confirm('Move {{.Name}} and its reports to the trash?')">
+                                        <button type="submit" class="text-red-600 hover:underline">Delete</button>
+                                    </form>
+                                </div>
+                            </td>
+                        </tr>
+                        {{else}}
+                        <tr>
+                            <td colspan="7" class="px-4 py-4 text-sm text-gray-400">No benchmarks yet, create one with masbench run.</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+        </div>
+    </div>
+
+    <!-- Footer -->
+    <div class="max-w-7xl mx-auto px-4 py-8 text-center text-sm text-gray-500">
+        <p>Served by masbench, reports are computed on every request</p>
+    </div>
+
+    <script>
+        function filterRows() {
+            const searchValue = document.getElementById('searchInput').value.toLowerCase();
+            document.querySelectorAll('tr.searchable').forEach(row => {
+                row.style.display = row.textContent.toLowerCase().includes(searchValue) ? '' : 'none';
+            });
+        }
+    </script>
+</body>
+</html>
+`
+
+const logsTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Logs of {{.Benchmark}}</title>
+    {{styles}}
+</head>
+<body class="bg-gray-50">
+    <div class="bg-white shadow-sm border-b border-gray-200">
+        <div class="max-w-7xl mx-auto px-4 py-6">
+            <h1 class="text-3xl font-bold text-gray-900">📜 Logs of {{.Benchmark}}</h1>
+            <p class="mt-2 text-sm text-gray-600"><a class="text-blue-600 hover:underline" href="/">← Back to the dashboard</a></p>
+        </div>
+    </div>
+
+    <div class="max-w-7xl mx-auto px-4 py-8">
+        <div class="bg-white rounded-lg shadow border border-gray-200">
+            <table class="min-w-full divide-y divide-gray-200">
+                <thead class="bg-gray-50">
+                    <tr>
+                        <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Level</th>
+                        <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Lines</th>
+                    </tr>
+                </thead>
+                <tbody class="bg-white divide-y divide-gray-200">
+                    {{range .Levels}}
+                    <tr>
+                        <td class="px-4 py-2 text-sm font-medium"><a class="text-blue-600 hover:underline" href="/benchmarks/{{$.Benchmark}}/logs/{{.LevelName}}">{{.LevelName}}</a></td>
+                        <td class="px-4 py-2 text-sm text-right text-gray-600">{{len .Lines}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+    </div>
+</body>
+</html>
+`
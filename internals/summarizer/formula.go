@@ -0,0 +1,233 @@
+package summarizer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// formulaVariables are the variables a scoring formula may use: the value of
+// the benchmark, the best and worst values among the benchmarks that solved
+// the level, and the configured timeout
+var formulaVariables = []string{"own", "best", "worst", "timeout"}
+
+// formula is a parsed arithmetic expression such as "best/own". It supports
+// numbers, the formulaVariables, + - * /, parentheses, min(a, b) and max(a, b)
+type formula struct {
+	root formulaNode
+}
+
+type formulaNode interface {
+	eval(vars map[string]float64) float64
+}
+
+type formulaNumber float64
+
+type formulaVariable string
+
+type formulaUnary struct {
+	operand formulaNode
+}
+
+type formulaBinary struct {
+	op          byte
+	left, right formulaNode
+}
+
+type formulaCall struct {
+	name string
+	args []formulaNode
+}
+
+func (n formulaNumber) eval(map[string]float64) float64 { return float64(n) }
+
+func (n formulaVariable) eval(vars map[string]float64) float64 { return vars[string(n)] }
+
+func (n formulaUnary) eval(vars map[string]float64) float64 { return -n.operand.eval(vars) }
+
+func (n formulaBinary) eval(vars map[string]float64) float64 {
+	left, right := n.left.eval(vars), n.right.eval(vars)
+	switch n.op {
+	case '+':
+		return left + right
+	case '-':
+		return left - right
+	case '*':
+		return left * right
+	default:
+		return left / right
+	}
+}
+
+func (n formulaCall) eval(vars map[string]float64) float64 {
+	if n.name == "min" {
+		return math.Min(n.args[0].eval(vars), n.args[1].eval(vars))
+	}
+	return math.Max(n.args[0].eval(vars), n.args[1].eval(vars))
+}
+
+// Eval returns the value of the formula for the given variables
+func (f formula) Eval(vars map[string]float64) float64 {
+	return f.root.eval(vars)
+}
+
+// parseFormula parses a scoring formula
+func parseFormula(source string) (formula, error) {
+	p := &formulaParser{tokens: tokenizeFormula(source)}
+	root, err := p.expression()
+	if err == nil && p.pos < len(p.tokens) {
+		err = fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	if err != nil {
+		return formula{}, fmt.Errorf("invalid formula %q: %w", source, err)
+	}
+	return formula{root: root}, nil
+}
+
+// tokenizeFormula splits a formula into numbers, names and single character
+// operators, spaces are dropped
+func tokenizeFormula(source string) []string {
+	var tokens []string
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.' || unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || unicode.IsLetter(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		default:
+			tokens = append(tokens, string(r))
+			i++
+		}
+	}
+	return tokens
+}
+
+type formulaParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *formulaParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *formulaParser) expect(token string) error {
+	if p.peek() != token {
+		if p.pos >= len(p.tokens) {
+			return fmt.Errorf("expected %q at the end", token)
+		}
+		return fmt.Errorf("expected %q, got %q", token, p.peek())
+	}
+	p.pos++
+	return nil
+}
+
+// expression parses terms joined by + and -
+func (p *formulaParser) expression() (formulaNode, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "+" || p.peek() == "-" {
+		op := p.tokens[p.pos][0]
+		p.pos++
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		left = formulaBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+// term parses factors joined by * and /
+func (p *formulaParser) term() (formulaNode, error) {
+	left, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "*" || p.peek() == "/" {
+		op := p.tokens[p.pos][0]
+		p.pos++
+		right, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		left = formulaBinary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *formulaParser) factor() (formulaNode, error) {
+	token := p.peek()
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end")
+	case token == "-":
+		p.pos++
+		operand, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return formulaUnary{operand: operand}, nil
+	case token == "(":
+		p.pos++
+		node, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		return node, p.expect(")")
+	case token == "min" || token == "max":
+		p.pos++
+		return p.call(token)
+	case unicode.IsLetter(rune(token[0])):
+		name := strings.ToLower(token)
+		for _, variable := range formulaVariables {
+			if name == variable {
+				p.pos++
+				return formulaVariable(name), nil
+			}
+		}
+		return nil, fmt.Errorf("unknown variable %q, expected one of %s", token, strings.Join(formulaVariables, ", "))
+	default:
+		value, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected %q", token)
+		}
+		p.pos++
+		return formulaNumber(value), nil
+	}
+}
+
+// call parses the two arguments of min or max
+func (p *formulaParser) call(name string) (formulaNode, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	first, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(","); err != nil {
+		return nil, err
+	}
+	second, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return formulaCall{name: name, args: []formulaNode{first, second}}, nil
+}
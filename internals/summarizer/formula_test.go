@@ -0,0 +1,69 @@
+package summarizer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFormula(t *testing.T) {
+	vars := map[string]float64{"own": 4, "best": 2, "worst": 8, "timeout": 180}
+	tests := []struct {
+		source string
+		want   float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"2 - 3 - 4", -5},
+		{"8 / 4 / 2", 1},
+		{"-2 * 3", -6},
+		{"--2", 2},
+		{"1.5 + .5", 2},
+		{"best / own", 0.5},
+		{"BEST/Own", 0.5},
+		{"(worst - own) / (worst - best)", 4.0 / 6},
+		{"min(own, timeout)", 4},
+		{"max(1, 2) + 1", 3},
+		{"min(max(best, 3), own * 2)", 3},
+		{"1 - own / timeout * 90", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			f, err := parseFormula(tt.source)
+			if err != nil {
+				t.Fatalf("parseFormula(%q) failed: %v", tt.source, err)
+			}
+			if got := f.Eval(vars); got != tt.want {
+				t.Errorf("parseFormula(%q).Eval() = %g, want %g", tt.source, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFormulaErrors(t *testing.T) {
+	tests := []struct {
+		source string
+		err    string
+	}{
+		{"", "unexpected end"},
+		{"1 +", "unexpected end"},
+		{"(1 + 2", `expected ")" at the end`},
+		{"own)", `unexpected ")"`},
+		{"1 2", `unexpected "2"`},
+		{"foo / own", `unknown variable "foo"`},
+		{"min(1)", `expected ","`},
+		{"max(1, 2", `expected ")" at the end`},
+		{"1..2", `unexpected "1..2"`},
+		{"own % 2", `unexpected "%"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			_, err := parseFormula(tt.source)
+			if err == nil {
+				t.Fatalf("parseFormula(%q) succeeded, want an error", tt.source)
+			}
+			if !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("parseFormula(%q) error = %q, want it to contain %q", tt.source, err, tt.err)
+			}
+		})
+	}
+}
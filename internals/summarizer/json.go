@@ -0,0 +1,12 @@
+package summarizer
+
+import (
+	"io"
+	"masbench/internals/models"
+	"masbench/internals/utils"
+)
+
+// WriteJSON writes the summary report as versioned JSON
+func WriteJSON(w io.Writer, report SummaryReport) error {
+	return utils.WriteJSONReport(w, models.ReportKindSummary, report)
+}
@@ -0,0 +1,193 @@
+package summarizer
+
+import (
+	"fmt"
+	"io"
+	"masbench/internals/markdown"
+	"strings"
+)
+
+// WriteMarkdown renders the summary report as GitHub-flavored Markdown,
+// ready to be pasted in a pull request description
+func WriteMarkdown(w io.Writer, report SummaryReport) error {
+	names := make([]string, len(report.Benchmarks))
+	for i, name := range report.Benchmarks {
+		names[i] = markdown.Code(name)
+	}
+	fmt.Fprintf(w, "## 📈 Benchmark Summary: %s\n\n", strings.Join(names, ", "))
+	fmt.Fprintf(w, "_Generated by masbench on %s. %d levels, unsolved levels count as the %ds timeout._\n\n",
+		report.GeneratedAt, report.OverallStats.TotalLevels, report.OverallStats.Timeout)
+
+	overall := markdown.NewTable(
+		markdown.Column{Header: "Category"},
+		markdown.Column{Header: "Winner"},
+		markdown.Column{Header: "Value", AlignRight: true},
+		markdown.Column{Header: "Details"},
+	)
+	addStats := func(category string, stats []BenchmarkStat) {
+		for _, stat := range stats {
+			overall.AddRow(category, "🏆 "+markdown.Code(stat.Name), markdown.Escape(stat.Value), markdown.Escape(stat.Extra))
+		}
+	}
+	addStats("Most levels solved", report.OverallStats.MostLevelsSolved)
+	addStats("Fastest total time", report.OverallStats.FastestCompletion)
+	addStats("Best average time", report.OverallStats.BestAvgTime)
+	addStats("Least memory", report.OverallStats.LeastMemory)
+	overall.AddRow("Most Pareto-optimal", "🏆 "+markdown.Escape(report.MostParetoOptimal), "", markdown.Escape(strings.Join(report.ParetoMetrics, ", ")))
+	if err := overall.Write(w); err != nil {
+		return err
+	}
+
+	individual := markdown.NewTable(
+		markdown.Column{Header: "Benchmark"},
+		markdown.Column{Header: "Solved", AlignRight: true},
+		markdown.Column{Header: "Total time", AlignRight: true},
+		markdown.Column{Header: "Avg time", AlignRight: true},
+		markdown.Column{Header: "Total actions", AlignRight: true},
+		markdown.Column{Header: "Pareto-optimal", AlignRight: true},
+		markdown.Column{Header: "Avg memory", AlignRight: true},
+	)
+	for _, stat := range report.IndividualStats {
+		individual.AddRow(
+			markdown.Code(stat.Name),
+			fmt.Sprintf("%d/%d (%.1f%%)", stat.LevelsSolved, stat.LevelsTotal, stat.SolvePercentage),
+			fmt.Sprintf("%.2fs", stat.TotalTime),
+			fmt.Sprintf("%.3fs", stat.AvgTime),
+			fmt.Sprintf("%.0f", stat.TotalActions),
+			fmt.Sprintf("%d", stat.ParetoOptimal),
+			fmt.Sprintf("%.2f MB", stat.AvgMemory),
+		)
+	}
+	if err := individual.Write(w); err != nil {
+		return err
+	}
+
+	if len(report.Benchmarks) > 1 {
+		if err := writePortfolioMarkdown(w, report.Portfolio); err != nil {
+			return err
+		}
+		if err := writeLeaderboardMarkdown(w, report.Leaderboard, report.Benchmarks); err != nil {
+			return err
+		}
+	}
+
+	return markdown.Details(w, fmt.Sprintf("Per-level results (%d levels)", len(report.LevelSummary)), func(w io.Writer) error {
+		levels := markdown.NewTable(
+			markdown.Column{Header: "Level"},
+			markdown.Column{Header: "Solved by"},
+			markdown.Column{Header: "Fastest", AlignRight: true},
+			markdown.Column{Header: "Fewest actions", AlignRight: true},
+			markdown.Column{Header: "Pareto front"},
+		)
+		for _, level := range report.LevelSummary {
+			mark := markdown.MarkSolved
+			if len(level.SolvedBy) == 0 {
+				mark = markdown.MarkUnsolved
+			} else if len(level.NotSolvedBy) > 0 {
+				mark = markdown.MarkMissing
+			}
+			levels.AddRow(
+				markdown.Escape(level.LevelName),
+				fmt.Sprintf("%s %d/%d", mark, len(level.SolvedBy), len(level.SolvedBy)+len(level.NotSolvedBy)),
+				markdown.Escape(level.FastestTime.DisplayValue),
+				markdown.Escape(level.FewestActions.DisplayValue),
+				markdown.Escape(strings.Join(level.ParetoFront, ", ")),
+			)
+		}
+		return levels.Write(w)
+	})
+}
+
+func writePortfolioMarkdown(w io.Writer, analysis PortfolioAnalysis) error {
+	vbs := analysis.VirtualBest
+	fmt.Fprintf(w, "### 🧩 Portfolio\n\n")
+	fmt.Fprintf(w, "**Virtual best solver:** %d/%d solved, %.2fs total time, PAR-2 %.2fs\n\n", vbs.Solved, vbs.TotalLevels, vbs.TotalTime, vbs.PAR2)
+
+	contributions := markdown.NewTable(
+		markdown.Column{Header: "Benchmark"},
+		markdown.Column{Header: "Only solved by it"},
+		markdown.Column{Header: "Fastest on", AlignRight: true},
+		markdown.Column{Header: "PAR-2 without it", AlignRight: true},
+	)
+	for _, contribution := range analysis.Contributions {
+		unique := fmt.Sprintf("%d", len(contribution.UniquelySolved))
+		if len(contribution.UniquelySolved) > 0 {
+			unique += " (" + markdown.Escape(strings.Join(contribution.UniquelySolved, ", ")) + ")"
+		}
+		contributions.AddRow(
+			markdown.Code(contribution.Benchmark),
+			unique,
+			fmt.Sprintf("%d", contribution.Picks),
+			fmt.Sprintf("+%.2fs", contribution.PAR2Increase),
+		)
+	}
+	if err := contributions.Write(w); err != nil {
+		return err
+	}
+
+	if len(analysis.BestPortfolios) == 0 {
+		return nil
+	}
+	portfolios := markdown.NewTable(
+		markdown.Column{Header: "Best portfolio"},
+		markdown.Column{Header: "Solved", AlignRight: true},
+		markdown.Column{Header: "Total time", AlignRight: true},
+		markdown.Column{Header: "PAR-2", AlignRight: true},
+	)
+	for _, portfolio := range analysis.BestPortfolios {
+		names := make([]string, len(portfolio.Benchmarks))
+		for i, name := range portfolio.Benchmarks {
+			names[i] = markdown.Code(name)
+		}
+		portfolios.AddRow(
+			strings.Join(names, " + "),
+			fmt.Sprintf("%d/%d", portfolio.Solved, portfolio.TotalLevels),
+			fmt.Sprintf("%.2fs", portfolio.TotalTime),
+			fmt.Sprintf("%.2fs", portfolio.PAR2),
+		)
+	}
+	return portfolios.Write(w)
+}
+
+func writeLeaderboardMarkdown(w io.Writer, board Leaderboard, benchmarkNames []string) error {
+	fmt.Fprintf(w, "### 🏁 Competition Score\n\n")
+
+	columns := []markdown.Column{
+		{Header: "Rank", AlignRight: true},
+		{Header: "Benchmark"},
+		{Header: "Score", AlignRight: true},
+	}
+	for _, rule := range board.Rules {
+		columns = append(columns, markdown.Column{Header: markdown.Escape(ruleLabel(rule)), AlignRight: true})
+	}
+	columns = append(columns, markdown.Column{Header: "Solved", AlignRight: true})
+
+	leaderboard := markdown.NewTable(columns...)
+	for _, entry := range board.Entries {
+		cells := []string{fmt.Sprintf("%d", entry.Rank), markdown.Code(entry.Benchmark), fmt.Sprintf("**%.2f**", entry.Score)}
+		for _, score := range entry.RuleScores {
+			cells = append(cells, fmt.Sprintf("%.2f", score))
+		}
+		cells = append(cells, fmt.Sprintf("%d", entry.LevelsSolved))
+		leaderboard.AddRow(cells...)
+	}
+	if err := leaderboard.Write(w); err != nil {
+		return err
+	}
+
+	return markdown.Details(w, fmt.Sprintf("Score per level (%d levels)", len(board.Levels)), func(w io.Writer) error {
+		columns := []markdown.Column{{Header: "Level"}}
+		for _, name := range benchmarkNames {
+			columns = append(columns, markdown.Column{Header: markdown.Code(name), AlignRight: true})
+		}
+		levels := markdown.NewTable(columns...)
+		for _, level := range board.Levels {
+			cells := []string{markdown.Escape(level.LevelName)}
+			for _, score := range level.Scores {
+				cells = append(cells, fmt.Sprintf("%.2f", score))
+			}
+			levels.AddRow(cells...)
+		}
+		return levels.Write(w)
+	})
+}
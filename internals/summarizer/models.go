@@ -1,66 +1,167 @@
 package summarizer
 
+import "masbench/internals/models"
+
 type SummaryReport struct {
-	Title           string
-	GeneratedAt     string
-	Benchmarks      []string
-	OverallStats    OverallStats
-	LevelSummary    []LevelSummary
-	BestByMetric    BestByMetric
-	IndividualStats []IndividualBenchmarkStats
+	Title             string                     `json:"title"`
+	GeneratedAt       string                     `json:"generatedAt"`
+	Benchmarks        []string                   `json:"benchmarks"`
+	OverallStats      OverallStats               `json:"overallStats"`
+	LevelSummary      []LevelSummary             `json:"levelSummary"`
+	ParetoMetrics     []string                   `json:"paretoMetrics"`
+	MostParetoOptimal string                     `json:"mostParetoOptimal"`
+	IndividualStats   []IndividualBenchmarkStats `json:"individualStats"`
+	Cactus            []CactusSeries             `json:"cactus"`
+	Profiles          []PerformanceProfile       `json:"performanceProfiles"`
+	Portfolio         PortfolioAnalysis          `json:"portfolio"`
+	Leaderboard       Leaderboard                `json:"leaderboard"`
 }
 
 type OverallStats struct {
-	TotalLevels       int
-	Timeout           int
-	MostLevelsSolved  []BenchmarkStat
-	FastestCompletion []BenchmarkStat
-	BestAvgTime       []BenchmarkStat
-	LeastMemory       []BenchmarkStat
-	MostEfficient     []BenchmarkStat
+	TotalLevels       int             `json:"totalLevels"`
+	Timeout           int             `json:"timeout"`
+	MostLevelsSolved  []BenchmarkStat `json:"mostLevelsSolved"`
+	FastestCompletion []BenchmarkStat `json:"fastestCompletion"`
+	BestAvgTime       []BenchmarkStat `json:"bestAvgTime"`
+	LeastMemory       []BenchmarkStat `json:"leastMemory"`
+	MostEfficient     []BenchmarkStat `json:"mostEfficient"`
 }
 
 type BenchmarkStat struct {
-	Name  string
-	Value string
-	Extra string
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Extra string `json:"extra"`
 }
 
 type LevelSummary struct {
-	LevelName            string
-	FastestTime          BenchmarkValue
-	FastestTimeWinners   []string
-	FewestActions        BenchmarkValue
-	FewestActionsWinners []string
-	SolvedBy             []string
-	NotSolvedBy          []string
+	LevelName     string         `json:"levelName"`
+	FastestTime   BenchmarkValue `json:"fastestTime"`
+	FewestActions BenchmarkValue `json:"fewestActions"`
+	ParetoFront   []string       `json:"paretoFront"` // benchmarks no other benchmark dominates on the level
+	Points        []ParetoPoint  `json:"points"`      // metrics of every benchmark that solved the level
+	SolvedBy      []string       `json:"solvedBy"`
+	NotSolvedBy   []string       `json:"notSolvedBy"`
 }
 
-type BenchmarkValue struct {
-	BenchmarkName string
-	Value         float64
-	DisplayValue  string
-	IsSolved      bool
+// ParetoPoint holds the metrics of a benchmark on a solved level, Optimal
+// tells whether it is on the Pareto front over the configured metrics
+type ParetoPoint struct {
+	Benchmark string  `json:"benchmark"`
+	Time      float64 `json:"time"`
+	Actions   float64 `json:"actions"`
+	Explored  float64 `json:"explored"`
+	Memory    float64 `json:"memory"`
+	Optimal   bool    `json:"optimal"`
+
+	missing map[string]bool // metrics without a value, reported as 0
 }
 
-type BestByMetric struct {
-	BestTime    string
-	BestActions string
+type BenchmarkValue struct {
+	BenchmarkName string  `json:"benchmarkName"`
+	Value         float64 `json:"value"`
+	DisplayValue  string  `json:"displayValue"`
+	IsSolved      bool    `json:"isSolved"`
 }
 
 type IndividualBenchmarkStats struct {
-	Name            string
-	LevelsSolved    int
-	LevelsTotal     int
-	SolvePercentage float64
-	TotalTime       float64
-	AvgTime         float64
-	TotalActions    float64
-	AvgActions      float64
-	TotalMemory     float64
-	AvgMemory       float64
-	TotalGenerated  float64
-	TotalExplored   float64
-	TimeWins        int
-	ActionWins      int
+	Name            string  `json:"name"`
+	LevelsSolved    int     `json:"levelsSolved"`
+	LevelsTotal     int     `json:"levelsTotal"`
+	SolvePercentage float64 `json:"solvePercentage"`
+	TotalTime       float64 `json:"totalTime"`
+	AvgTime         float64 `json:"avgTime"`
+	TotalActions    float64 `json:"totalActions"`
+	AvgActions      float64 `json:"avgActions"`
+	TotalMemory     float64 `json:"totalMemory"`
+	AvgMemory       float64 `json:"avgMemory"`
+	TotalGenerated  float64 `json:"totalGenerated"`
+	TotalExplored   float64 `json:"totalExplored"`
+	ParetoOptimal   int     `json:"paretoOptimal"` // levels where it is on the Pareto front
+}
+
+// CactusSeries is the number of levels a benchmark solves within a time budget
+type CactusSeries struct {
+	Benchmark string        `json:"benchmark"`
+	Points    []CactusPoint `json:"points"`
+}
+
+type CactusPoint struct {
+	Budget float64 `json:"budget"`
+	Solved int     `json:"solved"`
+}
+
+// PerformanceProfile is the Dolan-Moré performance profile of a benchmark,
+// a step function of the fraction of levels solved within a factor tau of
+// the best benchmark
+type PerformanceProfile struct {
+	Benchmark      string         `json:"benchmark"`
+	Points         []ProfilePoint `json:"points"`
+	BestFraction   float64        `json:"bestFraction"`   // fraction of levels where it is the best, at tau = 1
+	SolvedFraction float64        `json:"solvedFraction"` // fraction of levels solved, as tau grows
+}
+
+type ProfilePoint struct {
+	Tau      float64 `json:"tau"`
+	Fraction float64 `json:"fraction"`
+}
+
+// PortfolioAnalysis tells what a portfolio of the benchmarks would achieve
+type PortfolioAnalysis struct {
+	VirtualBest    VirtualBest            `json:"virtualBest"`
+	Contributions  []MarginalContribution `json:"contributions"`  // most valuable benchmark first
+	BestPortfolios []Portfolio            `json:"bestPortfolios"` // best portfolio of 2 and of 3 benchmarks
+}
+
+// Portfolio is a set of benchmarks scored as if the fastest of them were
+// used on every level
+type Portfolio struct {
+	Benchmarks  []string `json:"benchmarks"`
+	Solved      int      `json:"solved"`
+	TotalLevels int      `json:"totalLevels"`
+	TotalTime   float64  `json:"totalTime"` // unsolved levels count as the timeout
+	PAR2        float64  `json:"par2"`      // mean per level, unsolved levels count as twice the timeout
+}
+
+// VirtualBest is the portfolio of every benchmark, with its pick per level
+type VirtualBest struct {
+	Portfolio
+	Levels []VirtualBestLevel `json:"levels"`
+}
+
+type VirtualBestLevel struct {
+	LevelName string  `json:"levelName"`
+	Benchmark string  `json:"benchmark"` // fastest benchmark, empty when unsolved
+	Time      float64 `json:"time"`
+	Solved    bool    `json:"solved"`
+}
+
+// MarginalContribution is what a benchmark adds to the virtual best solver
+type MarginalContribution struct {
+	Benchmark      string   `json:"benchmark"`
+	UniquelySolved []string `json:"uniquelySolved"` // levels no other benchmark solves
+	Picks          int      `json:"picks"`          // levels where it is the fastest
+	PAR2Increase   float64  `json:"par2Increase"`   // PAR-2 of the virtual best solver without it, minus with it
+}
+
+// Leaderboard ranks the benchmarks by their competition score, the sum over
+// the levels of the scores of the rules
+type Leaderboard struct {
+	Rules   []models.ScoringRule `json:"rules"`
+	Entries []LeaderboardEntry   `json:"entries"` // highest score first
+	Levels  []LevelScores        `json:"levels"`
+}
+
+type LeaderboardEntry struct {
+	Rank         int       `json:"rank"`
+	Benchmark    string    `json:"benchmark"`
+	Score        float64   `json:"score"`
+	RuleScores   []float64 `json:"ruleScores"` // total per rule, in the order of Rules
+	LevelsSolved int       `json:"levelsSolved"`
+}
+
+// LevelScores holds the score of every benchmark on a level, in the order of
+// the report benchmarks
+type LevelScores struct {
+	LevelName string    `json:"levelName"`
+	Scores    []float64 `json:"scores"`
 }
@@ -0,0 +1,124 @@
+package summarizer
+
+import (
+	"fmt"
+	"masbench/internals/config"
+	"masbench/internals/models"
+	"masbench/internals/utils"
+	"math"
+)
+
+// getParetoMetrics returns the metrics the Pareto fronts are computed over
+func getParetoMetrics() []string {
+	return config.GetConfig().ParetoMetrics
+}
+
+// newParetoPoint reads the metrics of a solved level. Missing values are
+// reported as 0, count as the worst possible value in the front and are
+// never the best value of the level
+func newParetoPoint(name string, data map[string]string, metrics []string) (ParetoPoint, []float64) {
+	point := ParetoPoint{Benchmark: name, missing: make(map[string]bool)}
+	for _, metric := range []string{models.ColTime, models.ColActions, models.ColExplored, models.ColMemoryAlloc} {
+		value, ok := utils.LookupFloatFromMap(data, metric)
+		if !ok {
+			point.missing[metric] = true
+			continue
+		}
+		switch metric {
+		case models.ColTime:
+			point.Time = value
+		case models.ColActions:
+			point.Actions = value
+		case models.ColExplored:
+			point.Explored = value
+		case models.ColMemoryAlloc:
+			point.Memory = value
+		}
+	}
+
+	values := make([]float64, len(metrics))
+	for i, metric := range metrics {
+		value, ok := utils.LookupFloatFromMap(data, metric)
+		if !ok {
+			value = math.Inf(1)
+		}
+		values[i] = value
+	}
+	return point, values
+}
+
+// markParetoFront flags the points no other point dominates. values holds
+// the metrics of every point, lower is better for all of them
+func markParetoFront(points []ParetoPoint, values [][]float64, metrics []string) {
+	for i := range points {
+		points[i].Optimal = true
+		for j := range points {
+			if i != j && dominates(values[j], values[i], metrics) {
+				points[i].Optimal = false
+				break
+			}
+		}
+	}
+}
+
+// dominates reports whether a is no worse than b on every metric and better
+// on at least one. Differences within the metric tolerance are ties
+func dominates(a, b []float64, metrics []string) bool {
+	better := false
+	for i, metric := range metrics {
+		if a[i] == b[i] || getTolerance(metric).Within(a[i], b[i]) {
+			continue
+		}
+		if a[i] > b[i] {
+			return false
+		}
+		better = true
+	}
+	return better
+}
+
+// bestValue returns the lowest value of a metric among the solved points,
+// skipping the points without a value for it
+func bestValue(points []ParetoPoint, metric string, value func(ParetoPoint) float64, format string) BenchmarkValue {
+	if len(points) == 0 {
+		return BenchmarkValue{
+			BenchmarkName: "None",
+			DisplayValue:  "Not solved",
+			IsSolved:      false,
+		}
+	}
+
+	var best *ParetoPoint
+	for i, point := range points {
+		if point.missing[metric] {
+			continue
+		}
+		if best == nil || value(point) < value(*best) {
+			best = &points[i]
+		}
+	}
+	if best == nil {
+		return BenchmarkValue{
+			BenchmarkName: "None",
+			DisplayValue:  "n/a",
+			IsSolved:      false,
+		}
+	}
+	return BenchmarkValue{
+		BenchmarkName: best.Benchmark,
+		Value:         value(*best),
+		DisplayValue:  fmt.Sprintf(format, value(*best)),
+		IsSolved:      true,
+	}
+}
+
+// paretoCounts returns how many levels every benchmark is Pareto-optimal on
+func paretoCounts(summaries []LevelSummary) map[string]int {
+	counts := make(map[string]int)
+	for _, summary := range summaries {
+		for _, name := range summary.ParetoFront {
+			counts[name]++
+		}
+	}
+	return counts
+}
@@ -0,0 +1,172 @@
+package summarizer
+
+import (
+	"sort"
+
+	"github.com/go-gota/gota/dataframe"
+)
+
+// portfolioSizes are the sizes of the best portfolios searched for
+var portfolioSizes = []int{2, 3}
+
+// calculatePortfolios computes the virtual best solver of the benchmarks,
+// the marginal contribution of each of them and the best portfolios of a
+// few benchmarks
+func calculatePortfolios(dataframes map[string]dataframe.DataFrame, benchmarkNames []string, allLevels []string) PortfolioAnalysis {
+	timeout := float64(getDefaultTimeout())
+	times := make(map[string]map[string]float64, len(benchmarkNames))
+	for _, name := range benchmarkNames {
+		times[name] = solvedTimes(dataframes[name])
+	}
+
+	analysis := PortfolioAnalysis{
+		Contributions:  make([]MarginalContribution, 0, len(benchmarkNames)),
+		BestPortfolios: []Portfolio{},
+	}
+
+	var picks map[string]int
+	analysis.VirtualBest, picks = virtualBest(benchmarkNames, times, allLevels, timeout)
+
+	for _, name := range benchmarkNames {
+		others := make([]string, 0, len(benchmarkNames)-1)
+		for _, other := range benchmarkNames {
+			if other != name {
+				others = append(others, other)
+			}
+		}
+		without := evaluatePortfolio(others, times, allLevels, timeout)
+
+		contribution := MarginalContribution{
+			Benchmark:      name,
+			UniquelySolved: []string{},
+			Picks:          picks[name],
+			PAR2Increase:   without.PAR2 - analysis.VirtualBest.PAR2,
+		}
+		for _, level := range allLevels {
+			if _, ok := times[name][level]; ok && !solvedByAny(others, times, level) {
+				contribution.UniquelySolved = append(contribution.UniquelySolved, level)
+			}
+		}
+		analysis.Contributions = append(analysis.Contributions, contribution)
+	}
+	// Most valuable benchmarks first
+	sort.SliceStable(analysis.Contributions, func(i, j int) bool {
+		a, b := analysis.Contributions[i], analysis.Contributions[j]
+		if len(a.UniquelySolved) != len(b.UniquelySolved) {
+			return len(a.UniquelySolved) > len(b.UniquelySolved)
+		}
+		return a.PAR2Increase > b.PAR2Increase
+	})
+
+	for _, size := range portfolioSizes {
+		// A portfolio of every benchmark is the virtual best solver itself
+		if size >= len(benchmarkNames) {
+			break
+		}
+
+		var best Portfolio
+		found := false
+		for _, members := range combinations(benchmarkNames, size) {
+			portfolio := evaluatePortfolio(members, times, allLevels, timeout)
+			if !found || betterPortfolio(portfolio, best) {
+				best = portfolio
+				found = true
+			}
+		}
+		analysis.BestPortfolios = append(analysis.BestPortfolios, best)
+	}
+
+	return analysis
+}
+
+// virtualBest picks the fastest benchmark on every level, it also returns
+// how many levels every benchmark was picked for
+func virtualBest(benchmarkNames []string, times map[string]map[string]float64, allLevels []string, timeout float64) (VirtualBest, map[string]int) {
+	vbs := VirtualBest{
+		Portfolio: evaluatePortfolio(benchmarkNames, times, allLevels, timeout),
+		Levels:    make([]VirtualBestLevel, 0, len(allLevels)),
+	}
+	picks := make(map[string]int)
+
+	for _, level := range allLevels {
+		name, time, ok := fastest(benchmarkNames, times, level)
+		if !ok {
+			vbs.Levels = append(vbs.Levels, VirtualBestLevel{LevelName: level})
+			continue
+		}
+		picks[name]++
+		vbs.Levels = append(vbs.Levels, VirtualBestLevel{LevelName: level, Benchmark: name, Time: time, Solved: true})
+	}
+
+	return vbs, picks
+}
+
+// evaluatePortfolio scores a portfolio that gets, on every level, the time
+// of its fastest member. Unsolved levels count as the timeout in the total
+// time and as twice the timeout in the PAR-2 score
+func evaluatePortfolio(members []string, times map[string]map[string]float64, allLevels []string, timeout float64) Portfolio {
+	portfolio := Portfolio{Benchmarks: members, TotalLevels: len(allLevels)}
+
+	par2 := 0.0
+	for _, level := range allLevels {
+		_, time, ok := fastest(members, times, level)
+		if !ok {
+			portfolio.TotalTime += timeout
+			par2 += 2 * timeout
+			continue
+		}
+		portfolio.Solved++
+		portfolio.TotalTime += time
+		par2 += time
+	}
+	if len(allLevels) > 0 {
+		portfolio.PAR2 = par2 / float64(len(allLevels))
+	}
+
+	return portfolio
+}
+
+// fastest returns the member that solved a level in the least time
+func fastest(members []string, times map[string]map[string]float64, level string) (string, float64, bool) {
+	best, bestTime, found := "", 0.0, false
+	for _, name := range members {
+		time, ok := times[name][level]
+		if ok && (!found || time < bestTime) {
+			best, bestTime, found = name, time, true
+		}
+	}
+	return best, bestTime, found
+}
+
+func solvedByAny(members []string, times map[string]map[string]float64, level string) bool {
+	_, _, ok := fastest(members, times, level)
+	return ok
+}
+
+// betterPortfolio ranks portfolios by solved levels, then by total time
+func betterPortfolio(a, b Portfolio) bool {
+	if a.Solved != b.Solved {
+		return a.Solved > b.Solved
+	}
+	return a.TotalTime < b.TotalTime
+}
+
+// combinations returns every subset of size k of names, in order
+func combinations(names []string, k int) [][]string {
+	var result [][]string
+	var current []string
+	var pick func(start int)
+	pick = func(start int) {
+		if len(current) == k {
+			result = append(result, append([]string(nil), current...))
+			return
+		}
+		for i := start; i < len(names); i++ {
+			current = append(current, names[i])
+			pick(i + 1)
+			current = current[:len(current)-1]
+		}
+	}
+	pick(0)
+	return result
+}
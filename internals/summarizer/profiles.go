@@ -0,0 +1,108 @@
+package summarizer
+
+import (
+	"masbench/internals/models"
+	"masbench/internals/utils"
+	"math"
+	"sort"
+
+	"github.com/go-gota/gota/dataframe"
+)
+
+// minProfileValue is the smallest value used in performance ratios, so that
+// a level solved in 0s does not make every other ratio infinite
+const minProfileValue = 1e-3
+
+// ComputeCactus returns the number of levels solved within every budget,
+// from the values of the levels a benchmark solved
+func ComputeCactus(name string, values map[string]float64) CactusSeries {
+	sorted := make([]float64, 0, len(values))
+	for _, value := range values {
+		sorted = append(sorted, value)
+	}
+	sort.Float64s(sorted)
+
+	series := CactusSeries{Benchmark: name, Points: make([]CactusPoint, len(sorted))}
+	for i, value := range sorted {
+		series.Points[i] = CactusPoint{Budget: value, Solved: i + 1}
+	}
+	return series
+}
+
+// ComputePerformanceProfiles returns the Dolan-Moré performance profile of
+// every benchmark: the fraction of the levels it solved within a factor tau
+// of the best benchmark on that level. values holds, per benchmark, the
+// values of the levels it solved, levels is the total number of levels
+func ComputePerformanceProfiles(names []string, values []map[string]float64, levels int) []PerformanceProfile {
+	best := make(map[string]float64)
+	for _, benchmark := range values {
+		for level, value := range benchmark {
+			value = math.Max(value, minProfileValue)
+			if current, ok := best[level]; !ok || value < current {
+				best[level] = value
+			}
+		}
+	}
+
+	profiles := make([]PerformanceProfile, len(names))
+	for i, name := range names {
+		ratios := make([]float64, 0, len(values[i]))
+		for level, value := range values[i] {
+			ratios = append(ratios, math.Max(value, minProfileValue)/best[level])
+		}
+		sort.Float64s(ratios)
+
+		profile := PerformanceProfile{Benchmark: name, Points: []ProfilePoint{}}
+		if levels == 0 {
+			profiles[i] = profile
+			continue
+		}
+
+		for j, ratio := range ratios {
+			fraction := float64(j+1) / float64(levels)
+			if ratio <= 1 {
+				profile.BestFraction = fraction
+			}
+			// Keep only the last of equal ratios, the profile is a step function
+			if j+1 < len(ratios) && ratios[j+1] == ratio {
+				continue
+			}
+			profile.Points = append(profile.Points, ProfilePoint{Tau: ratio, Fraction: fraction})
+		}
+		if len(profile.Points) == 0 || profile.Points[0].Tau > 1 {
+			profile.Points = append([]ProfilePoint{{Tau: 1, Fraction: 0}}, profile.Points...)
+		}
+		profile.SolvedFraction = float64(len(ratios)) / float64(levels)
+
+		profiles[i] = profile
+	}
+
+	return profiles
+}
+
+// solvedTimes returns the time of every level a benchmark solved
+func solvedTimes(df dataframe.DataFrame) map[string]float64 {
+	times := make(map[string]float64)
+	for level, data := range utils.ToResolvedMap(df) {
+		if data[models.ColSolved] != models.SolvedYes {
+			continue
+		}
+		if value, ok := utils.LookupFloatFromMap(data, models.ColTime); ok {
+			times[level] = value
+		}
+	}
+	return times
+}
+
+// calculateProfiles computes the cactus data and the performance profiles
+// of the time of every benchmark
+func calculateProfiles(dataframes map[string]dataframe.DataFrame, benchmarkNames []string, allLevels []string) ([]CactusSeries, []PerformanceProfile) {
+	cactus := make([]CactusSeries, len(benchmarkNames))
+	times := make([]map[string]float64, len(benchmarkNames))
+	for i, name := range benchmarkNames {
+		times[i] = solvedTimes(dataframes[name])
+		cactus[i] = ComputeCactus(name, times[i])
+	}
+
+	return cactus, ComputePerformanceProfiles(benchmarkNames, times, len(allLevels))
+}
@@ -0,0 +1,140 @@
+package summarizer
+
+import (
+	"fmt"
+	"masbench/internals/config"
+	"masbench/internals/models"
+	"masbench/internals/utils"
+	"math"
+	"sort"
+
+	"github.com/go-gota/gota/dataframe"
+)
+
+// getScoringRules returns the configured competition scoring rules
+func getScoringRules() []models.ScoringRule {
+	return config.GetConfig().Scoring
+}
+
+// calculateScores scores every benchmark on every level with the scoring
+// rules and ranks them by total score. Values below minProfileValue are
+// raised to it, so that a level solved in 0s does not divide by zero
+func calculateScores(dataframes map[string]dataframe.DataFrame, benchmarkNames []string, allLevels []string, rules []models.ScoringRule) (Leaderboard, error) {
+	rules = append([]models.ScoringRule(nil), rules...)
+	formulas := make([]formula, len(rules))
+	for i, rule := range rules {
+		column, err := models.MetricColumn(rule.Metric)
+		if err != nil {
+			return Leaderboard{}, fmt.Errorf("invalid scoring rule: %w", err)
+		}
+		rules[i].Metric = column
+		rules[i].Weight = ruleWeight(rule)
+		if formulas[i], err = parseFormula(rule.Formula); err != nil {
+			return Leaderboard{}, fmt.Errorf("invalid scoring rule for %s: %w", column, err)
+		}
+	}
+
+	dfMaps := make([]map[string]map[string]string, len(benchmarkNames))
+	for i, name := range benchmarkNames {
+		dfMaps[i] = utils.ToResolvedMap(dataframes[name])
+	}
+
+	board := Leaderboard{
+		Rules:   rules,
+		Entries: make([]LeaderboardEntry, len(benchmarkNames)),
+		Levels:  make([]LevelScores, 0, len(allLevels)),
+	}
+	for i, name := range benchmarkNames {
+		board.Entries[i] = LeaderboardEntry{Benchmark: name, RuleScores: make([]float64, len(rules))}
+	}
+
+	timeout := float64(getDefaultTimeout())
+	for _, level := range allLevels {
+		levelScores := LevelScores{LevelName: level, Scores: make([]float64, len(benchmarkNames))}
+
+		// values[r][b] is the value of rule r for benchmark b, NaN if unsolved
+		values := make([][]float64, len(rules))
+		for r, rule := range rules {
+			values[r] = make([]float64, len(benchmarkNames))
+			for b := range benchmarkNames {
+				values[r][b] = math.NaN()
+				data, exists := dfMaps[b][level]
+				if !exists || data[models.ColSolved] != models.SolvedYes {
+					continue
+				}
+				if value, ok := utils.LookupFloatFromMap(data, rule.Metric); ok {
+					values[r][b] = math.Max(value, minProfileValue)
+				}
+			}
+		}
+
+		for r, rule := range rules {
+			best, worst := math.Inf(1), math.Inf(-1)
+			for _, value := range values[r] {
+				if !math.IsNaN(value) {
+					best, worst = math.Min(best, value), math.Max(worst, value)
+				}
+			}
+
+			for b, value := range values[r] {
+				if math.IsNaN(value) {
+					continue
+				}
+				score := formulas[r].Eval(map[string]float64{
+					"own":     value,
+					"best":    best,
+					"worst":   worst,
+					"timeout": timeout,
+				})
+				if math.IsNaN(score) || math.IsInf(score, 0) {
+					score = 0
+				}
+				score *= rule.Weight
+				levelScores.Scores[b] += score
+				board.Entries[b].RuleScores[r] += score
+			}
+		}
+
+		for b, score := range levelScores.Scores {
+			board.Entries[b].Score += score
+		}
+		board.Levels = append(board.Levels, levelScores)
+	}
+
+	for b := range benchmarkNames {
+		for _, data := range dfMaps[b] {
+			if data[models.ColSolved] == models.SolvedYes {
+				board.Entries[b].LevelsSolved++
+			}
+		}
+	}
+
+	// Highest score first, equal scores share a rank
+	sort.SliceStable(board.Entries, func(i, j int) bool {
+		return board.Entries[i].Score > board.Entries[j].Score
+	})
+	for i := range board.Entries {
+		board.Entries[i].Rank = i + 1
+		if i > 0 && board.Entries[i].Score == board.Entries[i-1].Score {
+			board.Entries[i].Rank = board.Entries[i-1].Rank
+		}
+	}
+
+	return board, nil
+}
+
+// ruleWeight returns the weight of a rule, rules without one weigh 1
+func ruleWeight(rule models.ScoringRule) float64 {
+	if rule.Weight == 0 {
+		return 1
+	}
+	return rule.Weight
+}
+
+// ruleLabel describes a rule, e.g. "Actions: best/own" or "Time: best/own ×2"
+func ruleLabel(rule models.ScoringRule) string {
+	if rule.Weight == 1 {
+		return fmt.Sprintf("%s: %s", rule.Metric, rule.Formula)
+	}
+	return fmt.Sprintf("%s: %s ×%g", rule.Metric, rule.Formula, rule.Weight)
+}
@@ -3,29 +3,37 @@ package summarizer
 import (
 	"fmt"
 	"html/template"
+	"io"
+	"masbench/internals/assets"
 	"masbench/internals/config"
 	"masbench/internals/models"
 	"masbench/internals/utils"
 	"math"
 	"os"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-gota/gota/dataframe"
 )
 
-const (
-	// TIME_TOLERANCE defines the tolerance for considering times as equal (in seconds)
-	// TODO: Make this configurable via parameter
-	TIME_TOLERANCE = 0.1
-)
-
 func GenerateHTMLSummary(benchmarkPaths map[string]string, outputPath string) error {
-	report, err := prepareSummaryData(benchmarkPaths)
+	report, err := PrepareSummaryData(benchmarkPaths)
 	if err != nil {
 		return fmt.Errorf("failed to prepare summary data: %w", err)
 	}
 
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer file.Close()
+
+	return WriteHTML(file, report)
+}
+
+// WriteHTML renders the summary report as an interactive HTML page
+func WriteHTML(w io.Writer, report SummaryReport) error {
 	funcMap := template.FuncMap{
 		"add": func(a, b any) float64 {
 			var aVal, bVal float64
@@ -43,6 +51,13 @@ func GenerateHTMLSummary(benchmarkPaths map[string]string, outputPath string) er
 			}
 			return aVal + bVal
 		},
+		"percent": func(fraction float64) float64 {
+			return fraction * 100
+		},
+		"join":      strings.Join,
+		"ruleLabel": ruleLabel,
+		"styles":    assets.Styles,
+		"script":    assets.ScriptTag,
 	}
 
 	tmpl, err := template.New("summary").Funcs(funcMap).Parse(summaryTemplate)
@@ -50,20 +65,15 @@ func GenerateHTMLSummary(benchmarkPaths map[string]string, outputPath string) er
 		return fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
-	}
-	defer file.Close()
-
-	if err := tmpl.Execute(file, report); err != nil {
+	if err := tmpl.Execute(w, report); err != nil {
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
 	return nil
 }
 
-func prepareSummaryData(benchmarkPaths map[string]string) (SummaryReport, error) {
+// PrepareSummaryData loads the results of every benchmark and computes the summary report
+func PrepareSummaryData(benchmarkPaths map[string]string) (SummaryReport, error) {
 	dataframes := make(map[string]dataframe.DataFrame)
 	benchmarkNames := make([]string, 0, len(benchmarkPaths))
 
@@ -81,15 +91,24 @@ func prepareSummaryData(benchmarkPaths map[string]string) (SummaryReport, error)
 	allLevels := collectAllLevels(dataframes)
 
 	report := SummaryReport{
-		Title:       "Benchmark Summary Report",
-		GeneratedAt: time.Now().Format("2006-01-02 15:04:05"),
-		Benchmarks:  benchmarkNames,
+		Title:         "Benchmark Summary Report",
+		GeneratedAt:   time.Now().Format("2006-01-02 15:04:05"),
+		Benchmarks:    benchmarkNames,
+		ParetoMetrics: getParetoMetrics(),
 	}
 
 	report.OverallStats = calculateOverallStats(dataframes, benchmarkNames, allLevels)
-	report.LevelSummary = calculateLevelSummary(dataframes, allLevels)
-	report.BestByMetric = determineBestByMetric(report.LevelSummary, benchmarkNames)
+	report.LevelSummary = calculateLevelSummary(dataframes, benchmarkNames, allLevels, report.ParetoMetrics)
+	report.MostParetoOptimal = findMaxWinner(paretoCounts(report.LevelSummary), benchmarkNames)
 	report.IndividualStats = calculateIndividualStats(dataframes, benchmarkNames, allLevels, report.LevelSummary)
+	report.Cactus, report.Profiles = calculateProfiles(dataframes, benchmarkNames, allLevels)
+	report.Portfolio = calculatePortfolios(dataframes, benchmarkNames, allLevels)
+
+	leaderboard, err := calculateScores(dataframes, benchmarkNames, allLevels, getScoringRules())
+	if err != nil {
+		return SummaryReport{}, err
+	}
+	report.Leaderboard = leaderboard
 
 	return report, nil
 }
@@ -127,7 +146,7 @@ func calculateOverallStats(dataframes map[string]dataframe.DataFrame, benchmarkN
 
 	for _, name := range benchmarkNames {
 		df := dataframes[name]
-		dfMap := utils.ToMap(df)
+		dfMap := utils.ToResolvedMap(df)
 
 		for _, level := range allLevels {
 			data, exists := dfMap[level]
@@ -259,100 +278,45 @@ func calculateOverallStats(dataframes map[string]dataframe.DataFrame, benchmarkN
 	return stats
 }
 
-func calculateLevelSummary(dataframes map[string]dataframe.DataFrame, allLevels []string) []LevelSummary {
+func calculateLevelSummary(dataframes map[string]dataframe.DataFrame, benchmarkNames []string, allLevels []string, paretoMetrics []string) []LevelSummary {
 	summaries := make([]LevelSummary, 0, len(allLevels))
+	dfMaps := make(map[string]map[string]map[string]string, len(benchmarkNames))
+	for _, name := range benchmarkNames {
+		dfMaps[name] = utils.ToResolvedMap(dataframes[name])
+	}
 
 	for _, level := range allLevels {
 		summary := LevelSummary{
-			LevelName: level,
+			LevelName:   level,
+			SolvedBy:    []string{},
+			NotSolvedBy: []string{},
+			ParetoFront: []string{},
+			Points:      []ParetoPoint{},
 		}
 
-		var timeWinners []string
-		var actionWinners []string
-		minTime := math.MaxFloat64
-		minActions := math.MaxFloat64
-
-		for name, df := range dataframes {
-			dfMap := utils.ToMap(df)
-			data, exists := dfMap[level]
-			if !exists {
-				summary.NotSolvedBy = append(summary.NotSolvedBy, name)
-				continue
-			}
-
-			solved := data[models.ColSolved]
-			if solved != models.SolvedYes {
+		var values [][]float64
+		for _, name := range benchmarkNames {
+			data, exists := dfMaps[name][level]
+			if !exists || data[models.ColSolved] != models.SolvedYes {
 				summary.NotSolvedBy = append(summary.NotSolvedBy, name)
 				continue
 			}
 
 			summary.SolvedBy = append(summary.SolvedBy, name)
-
-			// Track time winners (with tolerance)
-			timeVal := utils.GetFloatFromMap(data, models.ColTime)
-			if timeVal < minTime-TIME_TOLERANCE {
-				// New clear winner
-				minTime = timeVal
-				timeWinners = []string{name}
-			} else if math.Abs(timeVal-minTime) <= TIME_TOLERANCE {
-				// Tie - add to winners list
-				if !contains(timeWinners, name) {
-					timeWinners = append(timeWinners, name)
-				}
-			}
-
-			// Track action winners (exact comparison)
-			actionsVal := utils.GetFloatFromMap(data, models.ColActions)
-			if actionsVal < minActions {
-				// New clear winner
-				minActions = actionsVal
-				actionWinners = []string{name}
-			} else if actionsVal == minActions {
-				// Exact tie - add to winners list
-				if !contains(actionWinners, name) {
-					actionWinners = append(actionWinners, name)
-				}
-			}
+			point, pointValues := newParetoPoint(name, data, paretoMetrics)
+			summary.Points = append(summary.Points, point)
+			values = append(values, pointValues)
 		}
 
-		// Store time results
-		if len(timeWinners) > 0 {
-			summary.FastestTime = BenchmarkValue{
-				BenchmarkName: timeWinners[0], // Keep first for backward compatibility
-				Value:         minTime,
-				DisplayValue:  fmt.Sprintf("%.3fs", minTime),
-				IsSolved:      true,
-			}
-			summary.FastestTimeWinners = timeWinners
-		} else {
-			summary.FastestTime = BenchmarkValue{
-				BenchmarkName: "None",
-				DisplayValue:  "Not solved",
-				IsSolved:      false,
+		markParetoFront(summary.Points, values, paretoMetrics)
+		for _, point := range summary.Points {
+			if point.Optimal {
+				summary.ParetoFront = append(summary.ParetoFront, point.Benchmark)
 			}
-			summary.FastestTimeWinners = []string{}
 		}
 
-		// Store action results
-		if len(actionWinners) > 0 {
-			summary.FewestActions = BenchmarkValue{
-				BenchmarkName: actionWinners[0], // Keep first for backward compatibility
-				Value:         minActions,
-				DisplayValue:  fmt.Sprintf("%.0f", minActions),
-				IsSolved:      true,
-			}
-			summary.FewestActionsWinners = actionWinners
-		} else {
-			summary.FewestActions = BenchmarkValue{
-				BenchmarkName: "None",
-				DisplayValue:  "Not solved",
-				IsSolved:      false,
-			}
-			summary.FewestActionsWinners = []string{}
-		}
-
-		sort.Strings(summary.SolvedBy)
-		sort.Strings(summary.NotSolvedBy)
+		summary.FastestTime = bestValue(summary.Points, models.ColTime, func(p ParetoPoint) float64 { return p.Time }, "%.3fs")
+		summary.FewestActions = bestValue(summary.Points, models.ColActions, func(p ParetoPoint) float64 { return p.Actions }, "%.0f")
 
 		summaries = append(summaries, summary)
 	}
@@ -360,29 +324,6 @@ func calculateLevelSummary(dataframes map[string]dataframe.DataFrame, allLevels
 	return summaries
 }
 
-func determineBestByMetric(summaries []LevelSummary, benchmarkNames []string) BestByMetric {
-	timeWins := make(map[string]int)
-	actionWins := make(map[string]int)
-
-	for _, summary := range summaries {
-		// Credit ALL tied winners
-		for _, winner := range summary.FastestTimeWinners {
-			timeWins[winner]++
-		}
-		for _, winner := range summary.FewestActionsWinners {
-			actionWins[winner]++
-		}
-	}
-
-	bestTime := findMaxWinner(timeWins, benchmarkNames)
-	bestActions := findMaxWinner(actionWins, benchmarkNames)
-
-	return BestByMetric{
-		BestTime:    bestTime,
-		BestActions: bestActions,
-	}
-}
-
 func findMaxWinner(wins map[string]int, benchmarkNames []string) string {
 	maxWins := 0
 	winners := []string{}
@@ -418,30 +359,25 @@ func getDefaultTimeout() int {
 	return config.GetConfig().Timeout
 }
 
+// getTolerance returns the configured tolerance for a metric, shared with
+// the comparator so that ties mean the same thing in both reports
+func getTolerance(metric string) models.Tolerance {
+	return config.GetConfig().Tolerances[metric]
+}
+
 func calculateIndividualStats(dataframes map[string]dataframe.DataFrame, benchmarkNames []string, allLevels []string, levelSummaries []LevelSummary) []IndividualBenchmarkStats {
 	stats := make([]IndividualBenchmarkStats, 0, len(benchmarkNames))
 
-	timeWins := make(map[string]int)
-	actionWins := make(map[string]int)
-	for _, summary := range levelSummaries {
-		// Credit ALL tied winners
-		for _, winner := range summary.FastestTimeWinners {
-			timeWins[winner]++
-		}
-		for _, winner := range summary.FewestActionsWinners {
-			actionWins[winner]++
-		}
-	}
+	paretoOptimal := paretoCounts(levelSummaries)
 
 	for _, name := range benchmarkNames {
 		df := dataframes[name]
-		dfMap := utils.ToMap(df)
+		dfMap := utils.ToResolvedMap(df)
 
 		individual := IndividualBenchmarkStats{
-			Name:        name,
-			LevelsTotal: len(allLevels),
-			TimeWins:    timeWins[name],
-			ActionWins:  actionWins[name],
+			Name:          name,
+			LevelsTotal:   len(allLevels),
+			ParetoOptimal: paretoOptimal[name],
 		}
 
 		solvedCount := 0
@@ -518,13 +454,3 @@ func calculateIndividualStats(dataframes map[string]dataframe.DataFrame, benchma
 
 	return stats
 }
-
-// contains checks if a string slice contains a specific string
-func contains(slice []string, item string) bool {
-	for _, s := range slice {
-		if s == item {
-			return true
-		}
-	}
-	return false
-}
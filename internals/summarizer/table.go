@@ -0,0 +1,217 @@
+package summarizer
+
+import (
+	"fmt"
+	"io"
+	"masbench/internals/terminal"
+	"math"
+	"strings"
+)
+
+// WriteTable renders the summary report as terminal tables fitting width
+func WriteTable(w io.Writer, report SummaryReport, width int, color bool) error {
+	fmt.Fprintf(w, "%s\n", terminal.Paint(report.Title, terminal.ColorBold, color))
+	fmt.Fprintf(w, "%s • %s\n", strings.Join(report.Benchmarks, ", "), report.GeneratedAt)
+	fmt.Fprintf(w, "Total levels: %d • Timeout for unsolved levels: %ds\n\n", report.OverallStats.TotalLevels, report.OverallStats.Timeout)
+
+	overall := terminal.NewTable(
+		terminal.Column{Header: "Category"},
+		terminal.Column{Header: "Winner"},
+		terminal.Column{Header: "Value"},
+		terminal.Column{Header: "Details", Optional: true},
+	)
+	addStats := func(category string, stats []BenchmarkStat) {
+		for _, stat := range stats {
+			overall.AddRow(
+				terminal.Text(category),
+				terminal.Colored(stat.Name, terminal.ColorGreen),
+				terminal.Text(stat.Value),
+				terminal.Text(stat.Extra),
+			)
+		}
+	}
+	addStats("Most levels solved", report.OverallStats.MostLevelsSolved)
+	addStats("Fastest total time", report.OverallStats.FastestCompletion)
+	addStats("Best average time", report.OverallStats.BestAvgTime)
+	addStats("Least memory", report.OverallStats.LeastMemory)
+	overall.AddRow(terminal.Text("Most Pareto-optimal"), terminal.Colored(report.MostParetoOptimal, terminal.ColorGreen), terminal.Text(""), terminal.Text(strings.Join(report.ParetoMetrics, ", ")))
+	if err := overall.Render(w, width, color); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+
+	individual := terminal.NewTable(
+		terminal.Column{Header: "Benchmark"},
+		terminal.Column{Header: "Solved", AlignRight: true},
+		terminal.Column{Header: "%", AlignRight: true},
+		terminal.Column{Header: "Total time", AlignRight: true},
+		terminal.Column{Header: "Avg time", AlignRight: true},
+		terminal.Column{Header: "Total actions", AlignRight: true},
+		terminal.Column{Header: "Pareto-optimal", AlignRight: true, Optional: true},
+		terminal.Column{Header: "Avg memory", AlignRight: true, Optional: true},
+	)
+	for _, stat := range report.IndividualStats {
+		individual.AddRow(
+			terminal.Colored(stat.Name, terminal.ColorBlue),
+			terminal.Text(fmt.Sprintf("%d/%d", stat.LevelsSolved, stat.LevelsTotal)),
+			terminal.Text(fmt.Sprintf("%.1f%%", stat.SolvePercentage)),
+			terminal.Text(fmt.Sprintf("%.2fs", stat.TotalTime)),
+			terminal.Text(fmt.Sprintf("%.3fs", stat.AvgTime)),
+			terminal.Text(fmt.Sprintf("%.0f", stat.TotalActions)),
+			terminal.Text(fmt.Sprintf("%d", stat.ParetoOptimal)),
+			terminal.Text(fmt.Sprintf("%.2f MB", stat.AvgMemory)),
+		)
+	}
+	if err := individual.Render(w, width, color); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+
+	if len(report.Benchmarks) > 1 {
+		if err := writePortfolioTable(w, report.Portfolio, width, color); err != nil {
+			return err
+		}
+		if err := writeLeaderboardTable(w, report.Leaderboard, report.Benchmarks, width, color); err != nil {
+			return err
+		}
+	}
+
+	fastest := make([]float64, 0, len(report.LevelSummary))
+	for _, level := range report.LevelSummary {
+		if level.FastestTime.IsSolved {
+			fastest = append(fastest, level.FastestTime.Value)
+		} else {
+			fastest = append(fastest, math.NaN())
+		}
+	}
+	fmt.Fprintf(w, "Fastest time per level: %s\n\n", terminal.Sparkline(fastest))
+
+	levels := terminal.NewTable(
+		terminal.Column{Header: "Level"},
+		terminal.Column{Header: "Solved by"},
+		terminal.Column{Header: "Fastest", AlignRight: true},
+		terminal.Column{Header: "Fewest actions", AlignRight: true},
+		terminal.Column{Header: "Pareto front"},
+	)
+	for _, level := range report.LevelSummary {
+		solvedColor := terminal.ColorGreen
+		if len(level.SolvedBy) == 0 {
+			solvedColor = terminal.ColorRed
+		} else if len(level.NotSolvedBy) > 0 {
+			solvedColor = terminal.ColorYellow
+		}
+		levels.AddRow(
+			terminal.Text(level.LevelName),
+			terminal.Colored(solvedMeter(len(level.SolvedBy), len(level.SolvedBy)+len(level.NotSolvedBy)), solvedColor),
+			terminal.Text(level.FastestTime.DisplayValue),
+			terminal.Text(level.FewestActions.DisplayValue),
+			terminal.Colored(strings.Join(level.ParetoFront, ", "), terminal.ColorGreen),
+		)
+	}
+	return levels.Render(w, width, color)
+}
+
+func writePortfolioTable(w io.Writer, analysis PortfolioAnalysis, width int, color bool) error {
+	vbs := analysis.VirtualBest
+	fmt.Fprintf(w, "%s %d/%d solved, %.2fs total time, PAR-2 %.2fs\n\n",
+		terminal.Paint("Virtual best solver:", terminal.ColorBold, color), vbs.Solved, vbs.TotalLevels, vbs.TotalTime, vbs.PAR2)
+
+	contributions := terminal.NewTable(
+		terminal.Column{Header: "Benchmark"},
+		terminal.Column{Header: "Only solved by it", AlignRight: true},
+		terminal.Column{Header: "Fastest on", AlignRight: true},
+		terminal.Column{Header: "PAR-2 without it", AlignRight: true},
+		terminal.Column{Header: "Unique levels", Optional: true},
+	)
+	for _, contribution := range analysis.Contributions {
+		contributions.AddRow(
+			terminal.Colored(contribution.Benchmark, terminal.ColorBlue),
+			terminal.Text(fmt.Sprintf("%d", len(contribution.UniquelySolved))),
+			terminal.Text(fmt.Sprintf("%d", contribution.Picks)),
+			terminal.Text(fmt.Sprintf("+%.2fs", contribution.PAR2Increase)),
+			terminal.Text(strings.Join(contribution.UniquelySolved, ", ")),
+		)
+	}
+	if err := contributions.Render(w, width, color); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+
+	if len(analysis.BestPortfolios) == 0 {
+		return nil
+	}
+	portfolios := terminal.NewTable(
+		terminal.Column{Header: "Best portfolio"},
+		terminal.Column{Header: "Solved", AlignRight: true},
+		terminal.Column{Header: "Total time", AlignRight: true},
+		terminal.Column{Header: "PAR-2", AlignRight: true},
+	)
+	for _, portfolio := range analysis.BestPortfolios {
+		portfolios.AddRow(
+			terminal.Colored(strings.Join(portfolio.Benchmarks, " + "), terminal.ColorGreen),
+			terminal.Text(fmt.Sprintf("%d/%d", portfolio.Solved, portfolio.TotalLevels)),
+			terminal.Text(fmt.Sprintf("%.2fs", portfolio.TotalTime)),
+			terminal.Text(fmt.Sprintf("%.2fs", portfolio.PAR2)),
+		)
+	}
+	if err := portfolios.Render(w, width, color); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+	return nil
+}
+
+// solvedMeter draws how many benchmarks solved a level, e.g. "██░ 2/3"
+func solvedMeter(solved, total int) string {
+	return strings.Repeat("█", solved) + strings.Repeat("░", total-solved) + fmt.Sprintf(" %d/%d", solved, total)
+}
+
+func writeLeaderboardTable(w io.Writer, board Leaderboard, benchmarkNames []string, width int, color bool) error {
+	fmt.Fprintf(w, "%s\n\n", terminal.Paint("Competition score:", terminal.ColorBold, color))
+
+	columns := []terminal.Column{
+		{Header: "Rank", AlignRight: true},
+		{Header: "Benchmark"},
+		{Header: "Score", AlignRight: true},
+	}
+	for _, rule := range board.Rules {
+		columns = append(columns, terminal.Column{Header: ruleLabel(rule), AlignRight: true, Optional: true})
+	}
+	columns = append(columns, terminal.Column{Header: "Solved", AlignRight: true, Optional: true})
+
+	leaderboard := terminal.NewTable(columns...)
+	for _, entry := range board.Entries {
+		cells := []terminal.Cell{
+			terminal.Text(fmt.Sprintf("%d", entry.Rank)),
+			terminal.Colored(entry.Benchmark, terminal.ColorBlue),
+			terminal.Text(fmt.Sprintf("%.2f", entry.Score)),
+		}
+		for _, score := range entry.RuleScores {
+			cells = append(cells, terminal.Text(fmt.Sprintf("%.2f", score)))
+		}
+		cells = append(cells, terminal.Text(fmt.Sprintf("%d", entry.LevelsSolved)))
+		leaderboard.AddRow(cells...)
+	}
+	if err := leaderboard.Render(w, width, color); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+
+	columns = []terminal.Column{{Header: "Level"}}
+	for i, name := range benchmarkNames {
+		columns = append(columns, terminal.Column{Header: name, AlignRight: true, Optional: i > 0})
+	}
+	levels := terminal.NewTable(columns...)
+	for _, level := range board.Levels {
+		cells := []terminal.Cell{terminal.Text(level.LevelName)}
+		for _, score := range level.Scores {
+			cells = append(cells, terminal.Text(fmt.Sprintf("%.2f", score)))
+		}
+		levels.AddRow(cells...)
+	}
+	if err := levels.Render(w, width, color); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+	return nil
+}
@@ -6,7 +6,8 @@ const summaryTemplate = `<!DOCTYPE html>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
     <title>{{.Title}}</title>
-    <script src="https://cdn.tailwindcss.com"></script>
+    {{styles}}
+    {{script "chart.js"}}
     <style>
         body {
             color: #000000;
@@ -184,24 +185,14 @@ const summaryTemplate = `<!DOCTYPE html>
             </div>
             {{end}}
 
-            <!-- Best by Time -->
-            <div class="bg-blue-50 rounded-lg shadow border border-blue-200 p-6 metric-card">
+            <!-- Most Pareto-Optimal -->
+            <div class="bg-blue-50 rounded-lg shadow border border-blue-200 p-6 metric-card md:col-span-2">
                 <div class="flex items-center justify-between mb-3">
-                    <p class="text-sm font-medium text-blue-900">🏅 Best by Time</p>
-                    <div class="text-3xl">⏱️</div>
-                </div>
-                <p class="text-sm text-blue-700 mb-2">Most levels won</p>
-                <p class="text-lg font-bold text-blue-900">{{.BestByMetric.BestTime}}</p>
-            </div>
-
-            <!-- Best by Actions -->
-            <div class="bg-green-50 rounded-lg shadow border border-green-200 p-6 metric-card">
-                <div class="flex items-center justify-between mb-3">
-                    <p class="text-sm font-medium text-green-900">🎖️ Best by Actions</p>
+                    <p class="text-sm font-medium text-blue-900">🏅 Most Pareto-Optimal</p>
                     <div class="text-3xl">🎯</div>
                 </div>
-                <p class="text-sm text-green-700 mb-2">Most levels won</p>
-                <p class="text-lg font-bold text-green-900">{{.BestByMetric.BestActions}}</p>
+                <p class="text-sm text-blue-700 mb-2">Most levels where no other benchmark is better on every metric ({{join .ParetoMetrics ", "}})</p>
+                <p class="text-lg font-bold text-blue-900">{{.MostParetoOptimal}}</p>
             </div>
         </div>
     </div>
@@ -225,6 +216,242 @@ const summaryTemplate = `<!DOCTYPE html>
         </div>
     </div>
 
+    <!-- Cactus Plot and Performance Profiles -->
+    <div class="max-w-7xl mx-auto px-4 py-8">
+        <div class="bg-white rounded-lg shadow border border-gray-200 p-6">
+            <h2 class="text-2xl font-bold text-gray-900 mb-2">📉 Solver Comparison</h2>
+            <p class="text-sm text-gray-600 mb-6">
+                The cactus plot shows how many levels each benchmark solves within a time budget.
+                The performance profile shows, for every factor τ, the fraction of levels a benchmark
+                solves within τ times the time of the fastest benchmark on that level: higher is better,
+                its value at τ = 1 is the fraction of levels where it is the fastest.
+            </p>
+            <div class="grid grid-cols-1 md:grid-cols-2 gap-6">
+                <div>
+                    <h3 class="text-lg font-semibold text-gray-900 mb-3">Cactus Plot</h3>
+                    <canvas id="cactusChart"></canvas>
+                </div>
+                <div>
+                    <h3 class="text-lg font-semibold text-gray-900 mb-3">Performance Profile (Time)</h3>
+                    <canvas id="profileChart"></canvas>
+                </div>
+            </div>
+            <div class="overflow-x-auto mt-6">
+                <table class="min-w-full divide-y divide-gray-200">
+                    <thead class="bg-gray-50">
+                        <tr>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Benchmark</th>
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Fastest on (τ = 1)</th>
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Solved (τ → ∞)</th>
+                        </tr>
+                    </thead>
+                    <tbody class="bg-white divide-y divide-gray-200">
+                        {{range .Profiles}}
+                        <tr>
+                            <td class="px-4 py-2 text-sm font-medium text-gray-900">{{.Benchmark}}</td>
+                            <td class="px-4 py-2 text-sm text-right text-gray-900">{{printf "%.1f%%" (percent .BestFraction)}}</td>
+                            <td class="px-4 py-2 text-sm text-right text-gray-900">{{printf "%.1f%%" (percent .SolvedFraction)}}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+        </div>
+    </div>
+
+    <!-- Pareto Fronts -->
+    <div class="max-w-7xl mx-auto px-4 py-8">
+        <div class="bg-white rounded-lg shadow border border-gray-200 p-6">
+            <div class="flex justify-between items-center mb-2">
+                <h2 class="text-2xl font-bold text-gray-900">🎯 Pareto Fronts</h2>
+                <select id="paretoLevel" class="px-4 py-2 border border-gray-300 rounded-lg focus:outline-none focus:ring-2 focus:ring-blue-500" onchange="showParetoLevel(this.value)">
+                    {{range $index, $level := .LevelSummary}}
+                    <option value="{{$index}}">{{$level.LevelName}}</option>
+                    {{end}}
+                </select>
+            </div>
+            <p class="text-sm text-gray-600 mb-6">
+                A benchmark is Pareto-optimal on a level when no other benchmark is at least as good on every
+                metric ({{join .ParetoMetrics ", "}}) and better on one of them. Differences within the tolerances
+                are ties. Pareto-optimal benchmarks are drawn larger and joined by the dashed front.
+            </p>
+            <div class="grid grid-cols-1 md:grid-cols-2 gap-6">
+                <div>
+                    <canvas id="paretoChart"></canvas>
+                </div>
+                <div class="overflow-x-auto">
+                    <table class="min-w-full divide-y divide-gray-200">
+                        <thead class="bg-gray-50">
+                            <tr>
+                                <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Benchmark</th>
+                                <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Pareto-optimal on</th>
+                                <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Levels solved</th>
+                            </tr>
+                        </thead>
+                        <tbody class="bg-white divide-y divide-gray-200">
+                            {{range .IndividualStats}}
+                            <tr>
+                                <td class="px-4 py-2 text-sm font-medium text-gray-900">{{.Name}}</td>
+                                <td class="px-4 py-2 text-sm text-right text-gray-900">{{.ParetoOptimal}}</td>
+                                <td class="px-4 py-2 text-sm text-right text-gray-900">{{.LevelsSolved}} / {{.LevelsTotal}}</td>
+                            </tr>
+                            {{end}}
+                        </tbody>
+                    </table>
+                </div>
+            </div>
+        </div>
+    </div>
+
+    {{if gt (len .Benchmarks) 1}}
+    <!-- Portfolio Analysis -->
+    <div class="max-w-7xl mx-auto px-4 py-8">
+        <div class="bg-white rounded-lg shadow border border-gray-200 p-6">
+            <h2 class="text-2xl font-bold text-gray-900 mb-2">🧩 Portfolio Analysis</h2>
+            <p class="text-sm text-gray-600 mb-6">
+                The virtual best solver uses the fastest benchmark on every level: it is the best a portfolio
+                of these benchmarks could achieve. A benchmark is worth keeping when it solves levels no other
+                benchmark solves, or when the virtual best solver gets much slower without it.
+            </p>
+            {{with .Portfolio.VirtualBest}}
+            <div class="grid grid-cols-1 md:grid-cols-3 gap-4 mb-6">
+                <div class="bg-blue-50 rounded-lg p-4 border border-blue-200">
+                    <p class="text-xs font-medium text-blue-700 uppercase">Virtual Best Solved</p>
+                    <p class="text-2xl font-bold text-blue-900 mt-1">{{.Solved}} / {{.TotalLevels}}</p>
+                </div>
+                <div class="bg-purple-50 rounded-lg p-4 border border-purple-200">
+                    <p class="text-xs font-medium text-purple-700 uppercase">Virtual Best Total Time</p>
+                    <p class="text-2xl font-bold text-purple-900 mt-1">{{printf "%.2fs" .TotalTime}}</p>
+                </div>
+                <div class="bg-green-50 rounded-lg p-4 border border-green-200">
+                    <p class="text-xs font-medium text-green-700 uppercase">Virtual Best PAR-2</p>
+                    <p class="text-2xl font-bold text-green-900 mt-1">{{printf "%.2fs" .PAR2}}</p>
+                </div>
+            </div>
+            {{end}}
+            <div class="grid grid-cols-1 md:grid-cols-2 gap-6">
+                <div class="overflow-x-auto">
+                    <h3 class="text-lg font-semibold text-gray-900 mb-3">Marginal Contribution</h3>
+                    <table class="min-w-full divide-y divide-gray-200">
+                        <thead class="bg-gray-50">
+                            <tr>
+                                <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Benchmark</th>
+                                <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Only Solved By It</th>
+                                <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Fastest On</th>
+                                <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">PAR-2 Without It</th>
+                            </tr>
+                        </thead>
+                        <tbody class="bg-white divide-y divide-gray-200">
+                            {{range .Portfolio.Contributions}}
+                            <tr>
+                                <td class="px-4 py-2 text-sm font-medium text-gray-900">{{.Benchmark}}</td>
+                                <td class="px-4 py-2 text-sm text-gray-900">{{len .UniquelySolved}}{{if .UniquelySolved}} <span class="text-xs text-gray-500">({{join .UniquelySolved ", "}})</span>{{end}}</td>
+                                <td class="px-4 py-2 text-sm text-right text-gray-900">{{.Picks}} levels</td>
+                                <td class="px-4 py-2 text-sm text-right text-gray-900">+{{printf "%.2fs" .PAR2Increase}}</td>
+                            </tr>
+                            {{end}}
+                        </tbody>
+                    </table>
+                </div>
+                <div class="overflow-x-auto">
+                    <h3 class="text-lg font-semibold text-gray-900 mb-3">Best Portfolios</h3>
+                    {{if .Portfolio.BestPortfolios}}
+                    <table class="min-w-full divide-y divide-gray-200">
+                        <thead class="bg-gray-50">
+                            <tr>
+                                <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Benchmarks</th>
+                                <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Solved</th>
+                                <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Total Time</th>
+                                <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">PAR-2</th>
+                            </tr>
+                        </thead>
+                        <tbody class="bg-white divide-y divide-gray-200">
+                            {{range .Portfolio.BestPortfolios}}
+                            <tr>
+                                <td class="px-4 py-2 text-sm font-medium text-gray-900">{{join .Benchmarks " + "}}</td>
+                                <td class="px-4 py-2 text-sm text-right text-gray-900">{{.Solved}} / {{.TotalLevels}}</td>
+                                <td class="px-4 py-2 text-sm text-right text-gray-900">{{printf "%.2fs" .TotalTime}}</td>
+                                <td class="px-4 py-2 text-sm text-right text-gray-900">{{printf "%.2fs" .PAR2}}</td>
+                            </tr>
+                            {{end}}
+                        </tbody>
+                    </table>
+                    {{else}}
+                    <p class="text-sm text-gray-600">With two benchmarks the only portfolio is the virtual best solver.</p>
+                    {{end}}
+                </div>
+            </div>
+        </div>
+    </div>
+    {{end}}
+
+    {{if gt (len .Benchmarks) 1}}
+    <!-- Competition Score -->
+    <div class="max-w-7xl mx-auto px-4 py-8">
+        <div class="bg-white rounded-lg shadow border border-gray-200 p-6">
+            <h2 class="text-2xl font-bold text-gray-900 mb-2">🏁 Competition Score</h2>
+            <p class="text-sm text-gray-600 mb-6">
+                Every solved level is scored against the best benchmark on that level with the rules below,
+                unsolved levels score 0. The scores are relative to the selected benchmarks, add the other
+                competitors to predict your rank. Configure the rules with <code>Scoring</code> in masbench_config.yml.
+            </p>
+            <div class="overflow-x-auto">
+                <table class="min-w-full divide-y divide-gray-200">
+                    <thead class="bg-gray-50">
+                        <tr>
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Rank</th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Benchmark</th>
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Score</th>
+                            {{range .Leaderboard.Rules}}
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">{{ruleLabel .}}</th>
+                            {{end}}
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Solved</th>
+                        </tr>
+                    </thead>
+                    <tbody class="bg-white divide-y divide-gray-200">
+                        {{range .Leaderboard.Entries}}
+                        <tr>
+                            <td class="px-4 py-2 text-sm text-right font-bold text-gray-900">{{if eq .Rank 1}}🥇{{else if eq .Rank 2}}🥈{{else if eq .Rank 3}}🥉{{else}}{{.Rank}}{{end}}</td>
+                            <td class="px-4 py-2 text-sm font-medium text-gray-900">{{.Benchmark}}</td>
+                            <td class="px-4 py-2 text-sm text-right font-bold text-blue-600">{{printf "%.2f" .Score}}</td>
+                            {{range .RuleScores}}
+                            <td class="px-4 py-2 text-sm text-right text-gray-900">{{printf "%.2f" .}}</td>
+                            {{end}}
+                            <td class="px-4 py-2 text-sm text-right text-gray-900">{{.LevelsSolved}}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+            <details class="mt-6">
+                <summary class="text-lg font-semibold text-gray-900 cursor-pointer">Score per level</summary>
+                <div class="overflow-x-auto mt-3">
+                    <table class="min-w-full divide-y divide-gray-200">
+                        <thead class="bg-gray-50">
+                            <tr>
+                                <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Level</th>
+                                {{range .Benchmarks}}
+                                <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">{{.}}</th>
+                                {{end}}
+                            </tr>
+                        </thead>
+                        <tbody class="bg-white divide-y divide-gray-200">
+                            {{range .Leaderboard.Levels}}
+                            <tr>
+                                <td class="px-4 py-2 text-sm font-medium text-gray-900">{{.LevelName}}</td>
+                                {{range .Scores}}
+                                <td class="px-4 py-2 text-sm text-right {{if eq . 0.0}}text-red-600{{else}}text-gray-900{{end}}">{{printf "%.2f" .}}</td>
+                                {{end}}
+                            </tr>
+                            {{end}}
+                        </tbody>
+                    </table>
+                </div>
+            </details>
+        </div>
+    </div>
+    {{end}}
+
     <!-- Individual Benchmark Statistics -->
     <div class="max-w-7xl mx-auto px-4 py-8">
         <div class="bg-white rounded-lg shadow border border-gray-200">
@@ -310,22 +537,12 @@ const summaryTemplate = `<!DOCTYPE html>
                         </div>
                     </div>
 
-                    <!-- Wins Stats - Full Width -->
+                    <!-- Pareto Stats - Full Width -->
                     <div class="bg-white dark:bg-gray-800 rounded-lg p-4 border border-gray-200 dark:border-gray-700 mt-4">
-                        <h4 class="text-sm font-semibold text-gray-900 dark:text-gray-100 mb-3">🏆 Level Wins</h4>
-                        <div class="grid grid-cols-1 md:grid-cols-3 gap-4">
-                            <div class="flex justify-between">
-                                <span class="text-sm text-gray-600 dark:text-gray-300">⚡ Fastest Time Wins:</span>
-                                <span class="text-sm font-bold text-blue-600 dark:text-blue-400">{{$stat.TimeWins}} levels</span>
-                            </div>
-                            <div class="flex justify-between">
-                                <span class="text-sm text-gray-600 dark:text-gray-300">🎯 Fewest Actions Wins:</span>
-                                <span class="text-sm font-bold text-green-600 dark:text-green-400">{{$stat.ActionWins}} levels</span>
-                            </div>
-                            <div class="flex justify-between">
-                                <span class="text-sm text-gray-600 dark:text-gray-300">Total Wins:</span>
-                                <span class="text-sm font-bold text-gray-900 dark:text-gray-100">{{add $stat.TimeWins $stat.ActionWins}} wins</span>
-                            </div>
+                        <h4 class="text-sm font-semibold text-gray-900 dark:text-gray-100 mb-3">🎯 Pareto Front</h4>
+                        <div class="flex justify-between">
+                            <span class="text-sm text-gray-600 dark:text-gray-300">Pareto-optimal on:</span>
+                            <span class="text-sm font-bold text-blue-600 dark:text-blue-400">{{$stat.ParetoOptimal}} levels</span>
                         </div>
                     </div>
                 </div>
@@ -370,6 +587,9 @@ const summaryTemplate = `<!DOCTYPE html>
                             <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider sortable" onclick="sortTable(2)">
                                 🎯 Fewest Actions ↕
                             </th>
+                            <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">
+                                🎯 Pareto Front
+                            </th>
                             <th class="px-6 py-3 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">
                                 ✅ Solved By
                             </th>
@@ -385,27 +605,32 @@ const summaryTemplate = `<!DOCTYPE html>
                                 {{.LevelName}}
                             </td>
                             <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">
-                                {{if gt (len .FastestTimeWinners) 0}}
+                                {{if .FastestTime.IsSolved}}
                                     <div class="font-semibold text-blue-600">{{.FastestTime.DisplayValue}}</div>
-                                    <div class="flex flex-wrap gap-1 mt-1">
-                                        {{range .FastestTimeWinners}}
-                                            <span class="badge badge-success">{{.}}</span>
-                                        {{end}}
-                                    </div>
+                                {{else if .SolvedBy}}
+                                    <span class="text-gray-500">n/a</span>
                                 {{else}}
                                     <span class="text-red-600">Not solved</span>
                                 {{end}}
                             </td>
                             <td class="px-6 py-4 whitespace-nowrap text-sm text-gray-900">
-                                {{if gt (len .FewestActionsWinners) 0}}
+                                {{if .FewestActions.IsSolved}}
                                     <div class="font-semibold text-green-600">{{.FewestActions.DisplayValue}}</div>
-                                    <div class="flex flex-wrap gap-1 mt-1">
-                                        {{range .FewestActionsWinners}}
+                                {{else if .SolvedBy}}
+                                    <span class="text-gray-500">n/a</span>
+                                {{else}}
+                                    <span class="text-red-600">Not solved</span>
+                                {{end}}
+                            </td>
+                            <td class="px-6 py-4 text-sm text-gray-900">
+                                {{if .ParetoFront}}
+                                    <div class="flex flex-wrap gap-1">
+                                        {{range .ParetoFront}}
                                             <span class="badge badge-success">{{.}}</span>
                                         {{end}}
                                     </div>
                                 {{else}}
-                                    <span class="text-red-600">Not solved</span>
+                                    <span class="text-gray-400">None</span>
                                 {{end}}
                             </td>
                             <td class="px-6 py-4 text-sm text-gray-900">
@@ -445,6 +670,93 @@ const summaryTemplate = `<!DOCTYPE html>
     </div>
 
     <script>
+        // Cactus plot and performance profiles, colors match the static plots
+        const palette = ['rgb(59, 130, 246)', 'rgb(249, 115, 22)', 'rgb(34, 197, 94)', 'rgb(168, 85, 247)',
+            'rgb(239, 68, 68)', 'rgb(20, 184, 166)', 'rgb(236, 72, 153)', 'rgb(107, 114, 128)'];
+        const cactusData = {{.Cactus}};
+        const profileData = {{.Profiles}};
+        const maxTau = Math.max(1, ...profileData.flatMap(p => p.points.map(point => point.tau))) * 1.1;
+
+        new Chart(document.getElementById('cactusChart'), {
+            type: 'line',
+            data: {
+                datasets: cactusData.map((series, i) => ({
+                    label: series.benchmark,
+                    data: series.points.map(point => ({ x: point.budget, y: point.solved })),
+                    borderColor: palette[i % palette.length],
+                    backgroundColor: palette[i % palette.length],
+                    stepped: 'after',
+                    pointRadius: 2,
+                })),
+            },
+            options: {
+                scales: {
+                    x: { type: 'linear', title: { display: true, text: 'Time budget (s)' } },
+                    y: { beginAtZero: true, title: { display: true, text: 'Levels solved' }, ticks: { precision: 0 } },
+                },
+            },
+        });
+
+        new Chart(document.getElementById('profileChart'), {
+            type: 'line',
+            data: {
+                datasets: profileData.map((profile, i) => ({
+                    label: profile.benchmark,
+                    data: profile.points.map(point => ({ x: point.tau, y: point.fraction }))
+                        .concat([{ x: maxTau, y: profile.solvedFraction }]),
+                    borderColor: palette[i % palette.length],
+                    backgroundColor: palette[i % palette.length],
+                    stepped: 'after',
+                    pointRadius: 0,
+                })),
+            },
+            options: {
+                scales: {
+                    x: { type: 'logarithmic', min: 1, max: maxTau, title: { display: true, text: 'τ (time / fastest time)' } },
+                    y: { min: 0, max: 1, title: { display: true, text: 'Fraction of levels' } },
+                },
+            },
+        });
+
+        // Pareto front of the selected level, every benchmark keeps its color
+        const benchmarks = {{.Benchmarks}};
+        const levelData = {{.LevelSummary}};
+        const paretoChart = new Chart(document.getElementById('paretoChart'), {
+            type: 'scatter',
+            data: { datasets: [] },
+            options: {
+                scales: {
+                    x: { title: { display: true, text: 'Time (s)' } },
+                    y: { title: { display: true, text: 'Actions' }, ticks: { precision: 0 } },
+                },
+            },
+        });
+
+        function showParetoLevel(index) {
+            const points = levelData[index] ? levelData[index].points : [];
+            const front = points.filter(point => point.optimal).sort((a, b) => a.time - b.time);
+            paretoChart.data.datasets = points.map(point => {
+                const color = palette[benchmarks.indexOf(point.benchmark) % palette.length];
+                return {
+                    label: point.benchmark + (point.optimal ? ' (Pareto-optimal)' : ''),
+                    data: [{ x: point.time, y: point.actions }],
+                    borderColor: color,
+                    backgroundColor: color,
+                    pointRadius: point.optimal ? 8 : 4,
+                    pointStyle: point.optimal ? 'star' : 'circle',
+                };
+            }).concat([{
+                label: 'Pareto front',
+                data: front.map(point => ({ x: point.time, y: point.actions })),
+                showLine: true,
+                borderColor: 'rgb(107, 114, 128)',
+                borderDash: [6, 4],
+                pointRadius: 0,
+            }]);
+            paretoChart.update();
+        }
+        showParetoLevel(0);
+
         // Benchmark selector
         function selectBenchmark(index) {
             document.querySelectorAll('.benchmark-detail').forEach(el => el.classList.add('hidden'));
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package terminal
+
+import "os"
+
+// ttyWidth is not supported on this platform, Width falls back to $COLUMNS
+func ttyWidth(f *os.File) int {
+	return 0
+}
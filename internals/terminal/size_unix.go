@@ -0,0 +1,22 @@
+//go:build linux || darwin
+
+package terminal
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// ttyWidth returns the number of columns of the terminal attached to f,
+// 0 if f is not a terminal
+func ttyWidth(f *os.File) int {
+	var size struct {
+		rows, cols, xPixel, yPixel uint16
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return 0
+	}
+	return int(size.cols)
+}
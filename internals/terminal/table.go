@@ -0,0 +1,141 @@
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// columnGap separates two columns of a table
+const columnGap = "  "
+
+// minFirstColumnWidth is how narrow the first column may get before the
+// table is allowed to overflow the terminal
+const minFirstColumnWidth = 8
+
+// Cell is a single table cell, Color is applied when colors are enabled
+type Cell struct {
+	Text  string
+	Color string
+}
+
+// Text returns an uncolored cell
+func Text(text string) Cell {
+	return Cell{Text: text}
+}
+
+// Colored returns a cell drawn in the given color
+func Colored(text, color string) Cell {
+	return Cell{Text: text, Color: color}
+}
+
+// Column describes a table column
+type Column struct {
+	Header     string
+	AlignRight bool
+	// Optional columns are dropped, last first, when the table does not fit
+	Optional bool
+}
+
+// Table is a list of rows rendered with aligned columns
+type Table struct {
+	Columns []Column
+	Rows    [][]Cell
+}
+
+// NewTable returns an empty table with the given columns
+func NewTable(columns ...Column) *Table {
+	return &Table{Columns: columns}
+}
+
+// AddRow appends a row, missing cells are left empty
+func (t *Table) AddRow(cells ...Cell) {
+	t.Rows = append(t.Rows, cells)
+}
+
+// Render writes the table to w, fitting it into width columns by dropping
+// optional columns and then truncating the first column
+func (t *Table) Render(w io.Writer, width int, color bool) error {
+	widths := make([]int, len(t.Columns))
+	for i, col := range t.Columns {
+		widths[i] = displayWidth(col.Header)
+	}
+	for _, row := range t.Rows {
+		for i, cell := range row {
+			if i < len(widths) && displayWidth(cell.Text) > widths[i] {
+				widths[i] = displayWidth(cell.Text)
+			}
+		}
+	}
+
+	visible := make([]bool, len(t.Columns))
+	for i := range visible {
+		visible[i] = true
+	}
+
+	total := func() int {
+		sum, count := 0, 0
+		for i, w := range widths {
+			if visible[i] {
+				sum += w
+				count++
+			}
+		}
+		if count > 0 {
+			sum += (count - 1) * len(columnGap)
+		}
+		return sum
+	}
+
+	for i := len(t.Columns) - 1; i >= 0 && total() > width; i-- {
+		if t.Columns[i].Optional {
+			visible[i] = false
+		}
+	}
+	if overflow := total() - width; overflow > 0 && len(widths) > 0 {
+		widths[0] = max(minFirstColumnWidth, widths[0]-overflow)
+	}
+
+	header := make([]Cell, len(t.Columns))
+	for i, col := range t.Columns {
+		header[i] = Colored(col.Header, ColorBold)
+	}
+	if err := t.renderRow(w, header, widths, visible, color); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, Paint(strings.Repeat("─", total()), ColorGray, color)); err != nil {
+		return err
+	}
+
+	for _, row := range t.Rows {
+		if err := t.renderRow(w, row, widths, visible, color); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Table) renderRow(w io.Writer, row []Cell, widths []int, visible []bool, color bool) error {
+	parts := make([]string, 0, len(t.Columns))
+	for i, col := range t.Columns {
+		if !visible[i] {
+			continue
+		}
+		cell := Cell{}
+		if i < len(row) {
+			cell = row[i]
+		}
+
+		text := truncate(cell.Text, widths[i])
+		padding := strings.Repeat(" ", widths[i]-displayWidth(text))
+		if col.AlignRight {
+			text = padding + Paint(text, cell.Color, color)
+		} else {
+			text = Paint(text, cell.Color, color) + padding
+		}
+		parts = append(parts, text)
+	}
+
+	_, err := fmt.Fprintln(w, strings.TrimRight(strings.Join(parts, columnGap), " "))
+	return err
+}
@@ -0,0 +1,130 @@
+// Package terminal renders reports as colored, width-aware text tables
+package terminal
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultWidth is used when the width of the terminal cannot be detected
+const DefaultWidth = 120
+
+// ANSI color codes used for cells
+const (
+	ColorNone   = ""
+	ColorRed    = "\033[31m"
+	ColorGreen  = "\033[32m"
+	ColorYellow = "\033[33m"
+	ColorBlue   = "\033[34m"
+	ColorGray   = "\033[90m"
+	ColorBold   = "\033[1m"
+	colorReset  = "\033[0m"
+)
+
+// Width returns the width of the terminal attached to stdout, $COLUMNS
+// when set, DefaultWidth otherwise
+func Width() int {
+	if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+		return cols
+	}
+	if cols := ttyWidth(os.Stdout); cols > 0 {
+		return cols
+	}
+	return DefaultWidth
+}
+
+// ColorEnabled reports whether stdout is a terminal and NO_COLOR is unset
+func ColorEnabled() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Paint wraps text in the given color when enabled
+func Paint(text, color string, enabled bool) string {
+	if !enabled || color == ColorNone {
+		return text
+	}
+	return color + text + colorReset
+}
+
+// displayWidth is the number of terminal columns used by text
+func displayWidth(text string) int {
+	return utf8.RuneCountInString(text)
+}
+
+// truncate shortens text to width columns, ending it with an ellipsis
+func truncate(text string, width int) string {
+	if displayWidth(text) <= width {
+		return text
+	}
+	if width <= 1 {
+		return strings.Repeat("…", width)
+	}
+	runes := []rune(text)
+	return string(runes[:width-1]) + "…"
+}
+
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a single line of block characters scaled
+// between their minimum and maximum. NaN values are drawn as spaces and
+// infinite values, such as unsolved levels, as crosses
+func Sparkline(values []float64) string {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			continue
+		}
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		switch {
+		case math.IsNaN(v):
+			sb.WriteRune(' ')
+		case math.IsInf(v, 0):
+			sb.WriteRune('×')
+		case hi == lo:
+			sb.WriteRune(sparkLevels[len(sparkLevels)/2])
+		default:
+			idx := int((v - lo) / (hi - lo) * float64(len(sparkLevels)-1))
+			sb.WriteRune(sparkLevels[idx])
+		}
+	}
+	return sb.String()
+}
+
+// DeltaBar renders a percentage change as a bar of the given width centered
+// on an axis: negative changes grow to the left, positive to the right.
+// Changes beyond maxPct are clipped
+func DeltaBar(pct, maxPct float64, width int) string {
+	half := (width - 1) / 2
+	if maxPct <= 0 || math.IsNaN(pct) {
+		return strings.Repeat(" ", half) + "│" + strings.Repeat(" ", width-half-1)
+	}
+
+	cells := int(math.Round(math.Min(math.Abs(pct), maxPct) / maxPct * float64(half)))
+	if cells == 0 && pct != 0 {
+		cells = 1
+	}
+
+	left := strings.Repeat(" ", half)
+	right := strings.Repeat(" ", width-half-1)
+	if pct < 0 {
+		left = strings.Repeat(" ", half-cells) + strings.Repeat("█", cells)
+	} else if pct > 0 {
+		right = strings.Repeat("█", cells) + strings.Repeat(" ", width-half-1-cells)
+	}
+	return left + "│" + right
+}
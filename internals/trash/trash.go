@@ -0,0 +1,185 @@
+// Package trash keeps removed benchmarks and their reports in the .trash
+// folder of the benchmark folder, so that they can be restored
+package trash
+
+import (
+	"fmt"
+	"masbench/internals/index"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Folder is the trash folder in the benchmark folder
+const Folder = ".trash"
+
+// entryFile describes a trash entry, it is saved in the entry folder next to
+// the files folder holding the removed files
+const entryFile = "entry.yml"
+
+const filesFolder = "files"
+
+// Entry is a removed benchmark. Paths are the removed files and folders,
+// relative to the benchmark folder with forward slashes
+type Entry struct {
+	ID        string         `yaml:"-"`
+	Name      string         `yaml:"Name"`
+	DeletedAt time.Time      `yaml:"DeletedAt"`
+	Paths     []string       `yaml:"Paths"`
+	Reports   []index.Report `yaml:"Reports,omitempty"`
+}
+
+// Move moves a benchmark and its reports to the trash. Folders left empty by
+// the reports, such as the folder of a comparison, are removed
+func Move(folder, name string, reports []index.Report) (Entry, error) {
+	entry := Entry{Name: name, DeletedAt: time.Now(), Paths: []string{name}, Reports: reports}
+	entry.ID = fmt.Sprintf("%s-%s", name, entry.DeletedAt.Format("20060102-150405.000"))
+	for _, report := range reports {
+		// Reports written into the folder of the benchmark move with it
+		if !strings.HasPrefix(report.Path, name+"/") {
+			entry.Paths = append(entry.Paths, report.Path)
+		}
+	}
+
+	if err := checkPaths(entry); err != nil {
+		return Entry{}, err
+	}
+
+	entryDir := filepath.Join(folder, Folder, entry.ID)
+	if err := os.MkdirAll(filepath.Join(entryDir, filesFolder), 0755); err != nil {
+		return Entry{}, fmt.Errorf("failed to create %s: %w", entryDir, err)
+	}
+	if err := writeEntry(entryDir, entry); err != nil {
+		return Entry{}, err
+	}
+
+	for _, path := range entry.Paths {
+		source := filepath.Join(folder, filepath.FromSlash(path))
+		if err := moveFile(source, filepath.Join(entryDir, filesFolder, filepath.FromSlash(path))); err != nil {
+			return Entry{}, err
+		}
+		removeEmptyParents(folder, filepath.Dir(source))
+	}
+	return entry, nil
+}
+
+// List returns the entries of the trash, most recently removed first
+func List(folder string) ([]Entry, error) {
+	dirs, err := os.ReadDir(filepath.Join(folder, Folder))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the trash: %w", err)
+	}
+
+	var entries []Entry
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		entry, err := readEntry(filepath.Join(folder, Folder, dir.Name()))
+		if err != nil {
+			return nil, err
+		}
+		entry.ID = dir.Name()
+		entries = append(entries, entry)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].DeletedAt.After(entries[j].DeletedAt)
+	})
+	return entries, nil
+}
+
+// Restore moves the files of an entry back where they were and removes the
+// entry from the trash. Nothing is restored if one of the files exists again
+func Restore(folder string, entry Entry) error {
+	if err := checkPaths(entry); err != nil {
+		return err
+	}
+	for _, path := range entry.Paths {
+		if _, err := os.Stat(filepath.Join(folder, filepath.FromSlash(path))); err == nil {
+			return fmt.Errorf("cannot restore %s, %s exists", entry.Name, path)
+		}
+	}
+
+	entryDir := filepath.Join(folder, Folder, entry.ID)
+	for _, path := range entry.Paths {
+		source := filepath.Join(entryDir, filesFolder, filepath.FromSlash(path))
+		if err := moveFile(source, filepath.Join(folder, filepath.FromSlash(path))); err != nil {
+			return err
+		}
+	}
+	if err := os.RemoveAll(entryDir); err != nil {
+		return fmt.Errorf("failed to remove %s from the trash: %w", entry.ID, err)
+	}
+	return nil
+}
+
+// checkPaths returns an error unless every path of an entry stays inside the
+// benchmark folder, and so inside the files folder of the entry
+func checkPaths(entry Entry) error {
+	for _, path := range entry.Paths {
+		if path == "." || !filepath.IsLocal(filepath.FromSlash(path)) {
+			return fmt.Errorf("invalid path in the trash entry of %s: %s", entry.Name, path)
+		}
+	}
+	return nil
+}
+
+// moveFile moves a file or folder, creating the parents of the destination
+func moveFile(source, destination string) error {
+	if err := os.MkdirAll(filepath.Dir(destination), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(destination), err)
+	}
+	if err := os.Rename(source, destination); err != nil {
+		return fmt.Errorf("failed to move %s: %w", source, err)
+	}
+	return nil
+}
+
+// removeEmptyParents removes dir and its parents while they are empty,
+// stopping at the benchmark folder, which is never left
+func removeEmptyParents(folder, dir string) {
+	for {
+		relative, err := filepath.Rel(folder, dir)
+		if err != nil || relative == "." || !filepath.IsLocal(relative) {
+			return
+		}
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+func writeEntry(entryDir string, entry Entry) error {
+	data, err := yaml.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", entryFile, err)
+	}
+	if err := os.WriteFile(filepath.Join(entryDir, entryFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", entryFile, err)
+	}
+	return nil
+}
+
+func readEntry(entryDir string) (Entry, error) {
+	data, err := os.ReadFile(filepath.Join(entryDir, entryFile))
+	if err != nil {
+		return Entry{}, fmt.Errorf("failed to read %s: %w", entryDir, err)
+	}
+
+	var entry Entry
+	if err := yaml.Unmarshal(data, &entry); err != nil {
+		return Entry{}, fmt.Errorf("failed to parse %s: %w", filepath.Join(entryDir, entryFile), err)
+	}
+	if entry.Name == "" {
+		return Entry{}, fmt.Errorf("trash entry without a name: %s", entryDir)
+	}
+	return entry, nil
+}
@@ -0,0 +1,29 @@
+package trend
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"masbench/internals/assets"
+	"masbench/internals/terminal"
+)
+
+// WriteHTML renders the trend report as an interactive HTML page
+func WriteHTML(w io.Writer, report Report) error {
+	funcMap := template.FuncMap{
+		"sparkline": terminal.Sparkline,
+		"styles":    assets.Styles,
+		"script":    assets.ScriptTag,
+	}
+
+	tmpl, err := template.New("trend").Funcs(funcMap).Parse(trendTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	if err := tmpl.Execute(w, report); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,12 @@
+package trend
+
+import (
+	"io"
+	"masbench/internals/models"
+	"masbench/internals/utils"
+)
+
+// WriteJSON writes the trend report as versioned JSON
+func WriteJSON(w io.Writer, report Report) error {
+	return utils.WriteJSONReport(w, models.ReportKindTrend, report)
+}
@@ -0,0 +1,70 @@
+package trend
+
+import (
+	"fmt"
+	"io"
+	"masbench/internals/markdown"
+	"masbench/internals/terminal"
+	"strings"
+)
+
+// WriteMarkdown renders the trend report as GitHub-flavored Markdown
+func WriteMarkdown(w io.Writer, report Report) error {
+	fmt.Fprintf(w, "## 📈 Benchmark Trend\n\n")
+	fmt.Fprintf(w, "_Generated by masbench on %s. %d runs, unsolved levels count as the %ds timeout._\n\n",
+		report.GeneratedAt, len(report.Runs), report.Timeout)
+
+	runs := markdown.NewTable(
+		markdown.Column{Header: "Run"},
+		markdown.Column{Header: "Date"},
+		markdown.Column{Header: "Solved", AlignRight: true},
+		markdown.Column{Header: "Total time", AlignRight: true},
+		markdown.Column{Header: "Geomean time", AlignRight: true},
+		markdown.Column{Header: "Total actions", AlignRight: true},
+		markdown.Column{Header: "Geomean actions", AlignRight: true},
+		markdown.Column{Header: "Description"},
+	)
+	for _, run := range report.Runs {
+		runs.AddRow(
+			markdown.Code(run.Name),
+			run.StartedAt.Format("2006-01-02 15:04"),
+			fmt.Sprintf("%d/%d", run.Solved, run.TotalLevels),
+			fmt.Sprintf("%.2fs", run.TotalTime),
+			fmt.Sprintf("%.3fs", run.GeomeanTime),
+			fmt.Sprintf("%.0f", run.TotalActions),
+			fmt.Sprintf("%.1f", run.GeomeanActions),
+			markdown.Escape(run.Description),
+		)
+	}
+	if err := runs.Write(w); err != nil {
+		return err
+	}
+
+	return markdown.Details(w, fmt.Sprintf("Per-level history (%d levels)", len(report.Levels)), func(w io.Writer) error {
+		levels := markdown.NewTable(
+			markdown.Column{Header: "Level"},
+			markdown.Column{Header: "Time"},
+			markdown.Column{Header: "Solved", AlignRight: true},
+			markdown.Column{Header: "First solved"},
+			markdown.Column{Header: "Regressed in"},
+		)
+		for _, level := range report.Levels {
+			firstSolved := markdown.MarkUnsolved + " never"
+			if level.FirstSolved != "" {
+				firstSolved = markdown.Code(level.FirstSolved)
+			}
+			regressed := make([]string, len(level.RegressedIn))
+			for i, name := range level.RegressedIn {
+				regressed[i] = markdown.Code(name)
+			}
+			levels.AddRow(
+				markdown.Escape(level.LevelName),
+				"`"+terminal.Sparkline(level.TimeSeries())+"`",
+				fmt.Sprintf("%d/%d", level.SolvedRuns(), level.PresentRuns()),
+				firstSolved,
+				strings.Join(regressed, ", "),
+			)
+		}
+		return levels.Write(w)
+	})
+}
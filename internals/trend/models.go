@@ -0,0 +1,38 @@
+package trend
+
+import "time"
+
+// Report follows a series of benchmark runs over time, oldest run first
+type Report struct {
+	Title       string         `json:"title"`
+	GeneratedAt string         `json:"generatedAt"`
+	Timeout     int            `json:"timeout"`
+	Runs        []Run          `json:"runs"`
+	Levels      []LevelHistory `json:"levels"`
+}
+
+// Run holds the headline numbers of one benchmark of the series
+type Run struct {
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	StartedAt      time.Time `json:"startedAt"`
+	Solved         int       `json:"solved"`
+	TotalLevels    int       `json:"totalLevels"`    // levels of the series run by this benchmark
+	TotalTime      float64   `json:"totalTime"`      // unsolved levels count as the timeout
+	GeomeanTime    float64   `json:"geomeanTime"`    // over the solved levels
+	TotalActions   float64   `json:"totalActions"`   // over the solved levels
+	GeomeanActions float64   `json:"geomeanActions"` // over the solved levels
+}
+
+// LevelHistory holds the results of a level in every run, in the order of
+// the report runs. Times and Actions are 0 where the level was not solved or
+// not run
+type LevelHistory struct {
+	LevelName   string    `json:"levelName"`
+	Present     []bool    `json:"present"` // whether each run ran the level
+	Solved      []bool    `json:"solved"`
+	Times       []float64 `json:"times"`
+	Actions     []float64 `json:"actions"`
+	FirstSolved string    `json:"firstSolved"` // run where the level was solved for the first time, empty if never
+	RegressedIn []string  `json:"regressedIn"` // runs no longer solving the level, ignoring the runs without it
+}
@@ -0,0 +1,77 @@
+package trend
+
+import (
+	"fmt"
+	"io"
+	"masbench/internals/terminal"
+	"strings"
+)
+
+// WriteTable renders the trend report as terminal tables fitting width
+func WriteTable(w io.Writer, report Report, width int, color bool) error {
+	fmt.Fprintf(w, "%s\n", terminal.Paint(report.Title, terminal.ColorBold, color))
+	fmt.Fprintf(w, "%d runs • %s\n\n", len(report.Runs), report.GeneratedAt)
+
+	solved := make([]float64, len(report.Runs))
+	geomeanTimes := make([]float64, len(report.Runs))
+	for i, run := range report.Runs {
+		solved[i] = float64(run.Solved)
+		geomeanTimes[i] = run.GeomeanTime
+	}
+	fmt.Fprintf(w, "Solved levels: %s  Geomean time: %s\n\n", terminal.Sparkline(solved), terminal.Sparkline(geomeanTimes))
+
+	runs := terminal.NewTable(
+		terminal.Column{Header: "Run"},
+		terminal.Column{Header: "Date"},
+		terminal.Column{Header: "Solved", AlignRight: true},
+		terminal.Column{Header: "Total time", AlignRight: true},
+		terminal.Column{Header: "Geomean time", AlignRight: true},
+		terminal.Column{Header: "Total actions", AlignRight: true, Optional: true},
+		terminal.Column{Header: "Geomean actions", AlignRight: true, Optional: true},
+		terminal.Column{Header: "Description", Optional: true},
+	)
+	for i, run := range report.Runs {
+		solvedColor := terminal.ColorNone
+		if i > 0 && run.Solved > report.Runs[i-1].Solved {
+			solvedColor = terminal.ColorGreen
+		} else if i > 0 && run.Solved < report.Runs[i-1].Solved {
+			solvedColor = terminal.ColorRed
+		}
+		runs.AddRow(
+			terminal.Colored(run.Name, terminal.ColorBlue),
+			terminal.Text(run.StartedAt.Format("2006-01-02 15:04")),
+			terminal.Colored(fmt.Sprintf("%d/%d", run.Solved, run.TotalLevels), solvedColor),
+			terminal.Text(fmt.Sprintf("%.2fs", run.TotalTime)),
+			terminal.Text(fmt.Sprintf("%.3fs", run.GeomeanTime)),
+			terminal.Text(fmt.Sprintf("%.0f", run.TotalActions)),
+			terminal.Text(fmt.Sprintf("%.1f", run.GeomeanActions)),
+			terminal.Text(run.Description),
+		)
+	}
+	if err := runs.Render(w, width, color); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+
+	levels := terminal.NewTable(
+		terminal.Column{Header: "Level"},
+		terminal.Column{Header: "Time"},
+		terminal.Column{Header: "Solved", AlignRight: true},
+		terminal.Column{Header: "First solved"},
+		terminal.Column{Header: "Regressed in", Optional: true},
+	)
+	for _, level := range report.Levels {
+		firstSolved := terminal.Text(level.FirstSolved)
+		if level.FirstSolved == "" {
+			firstSolved = terminal.Colored("never", terminal.ColorRed)
+		}
+		levels.AddRow(
+			terminal.Text(level.LevelName),
+			terminal.Text(terminal.Sparkline(level.TimeSeries())),
+			terminal.Text(fmt.Sprintf("%d/%d", level.SolvedRuns(), level.PresentRuns())),
+			firstSolved,
+			terminal.Colored(strings.Join(level.RegressedIn, ", "), terminal.ColorRed),
+		)
+	}
+	return levels.Render(w, width, color)
+}
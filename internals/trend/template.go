@@ -0,0 +1,229 @@
+package trend
+
+const trendTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>{{.Title}}</title>
+    {{styles}}
+    {{script "chart.js"}}
+    <style>
+        body {
+            color: #000000;
+            background-color: #f9fafb;
+        }
+        .dark {
+            background-color: #111827 !important;
+        }
+        .dark .bg-white { background-color: #1f2937 !important; }
+        .dark .bg-gray-50 { background-color: #111827 !important; }
+        .dark .border-gray-200 { border-color: #4b5563 !important; }
+        .dark thead { background-color: #1f2937 !important; }
+        .dark tbody { background-color: #1f2937 !important; }
+        .dark .divide-gray-200 > * { border-color: #4b5563 !important; }
+        .badge {
+            display: inline-block;
+            padding: 0.25rem 0.75rem;
+            border-radius: 9999px;
+            font-size: 0.75rem;
+            font-weight: 600;
+        }
+        .badge-success {
+            background-color: #dcfce7;
+            color: #166534;
+        }
+        .badge-danger {
+            background-color: #fee2e2;
+            color: #991b1b;
+        }
+        .sparkline {
+            font-family: ui-monospace, SFMono-Regular, Menlo, monospace;
+            letter-spacing: 1px;
+        }
+    </style>
+</head>
+<body class="bg-gray-50 transition-colors duration-200">
+    <!-- Header -->
+    <div class="bg-white shadow-sm border-b border-gray-200">
+        <div class="max-w-7xl mx-auto px-4 py-6">
+            <div class="flex justify-between items-center">
+                <div>
+                    <h1 class="text-3xl font-bold text-gray-900">📈 Benchmark Trend Report</h1>
+                    <div class="mt-2 flex items-center gap-2 text-sm text-gray-600 flex-wrap">
+                        <span class="font-semibold text-blue-600">{{len .Runs}} runs</span>
+                        <span class="text-gray-400">•</span>
+                        <span>{{.GeneratedAt}}</span>
+                    </div>
+                </div>
+                <button onclick="toggleDarkMode()" class="px-4 py-2 bg-gray-800 text-white rounded-lg hover:bg-gray-700 transition">
+                    🌙 Dark Mode
+                </button>
+            </div>
+        </div>
+    </div>
+
+    <!-- Charts -->
+    <div class="max-w-7xl mx-auto px-4 py-8">
+        <div class="bg-white rounded-lg shadow border border-gray-200 p-6">
+            <h2 class="text-2xl font-bold text-gray-900 mb-2">📉 Progress</h2>
+            <p class="text-sm text-gray-600 mb-6">
+                Runs are ordered by the time they were started. The total time counts unsolved levels as the
+                {{.Timeout}}s timeout, geometric means and total actions only cover the levels each run solved.
+            </p>
+            <div class="grid grid-cols-1 md:grid-cols-3 gap-6">
+                <div>
+                    <h3 class="text-lg font-semibold text-gray-900 mb-3">Levels Solved</h3>
+                    <canvas id="solvedChart"></canvas>
+                </div>
+                <div>
+                    <h3 class="text-lg font-semibold text-gray-900 mb-3">Time</h3>
+                    <canvas id="timeChart"></canvas>
+                </div>
+                <div>
+                    <h3 class="text-lg font-semibold text-gray-900 mb-3">Actions</h3>
+                    <canvas id="actionsChart"></canvas>
+                </div>
+            </div>
+        </div>
+    </div>
+
+    <!-- Runs -->
+    <div class="max-w-7xl mx-auto px-4 py-8">
+        <div class="bg-white rounded-lg shadow border border-gray-200">
+            <div class="p-6 border-b border-gray-200">
+                <h2 class="text-2xl font-bold text-gray-900">🏃 Runs</h2>
+            </div>
+            <div class="overflow-x-auto">
+                <table class="min-w-full divide-y divide-gray-200">
+                    <thead class="bg-gray-50">
+                        <tr>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Run</th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Date</th>
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Solved</th>
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Total Time</th>
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Geomean Time</th>
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Total Actions</th>
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Geomean Actions</th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Description</th>
+                        </tr>
+                    </thead>
+                    <tbody class="bg-white divide-y divide-gray-200">
+                        {{range .Runs}}
+                        <tr>
+                            <td class="px-4 py-2 text-sm font-medium text-gray-900">{{.Name}}</td>
+                            <td class="px-4 py-2 text-sm text-gray-600 whitespace-nowrap">{{.StartedAt.Format "2006-01-02 15:04"}}</td>
+                            <td class="px-4 py-2 text-sm text-right text-gray-900">{{.Solved}} / {{.TotalLevels}}</td>
+                            <td class="px-4 py-2 text-sm text-right text-gray-900">{{printf "%.2fs" .TotalTime}}</td>
+                            <td class="px-4 py-2 text-sm text-right text-gray-900">{{printf "%.3fs" .GeomeanTime}}</td>
+                            <td class="px-4 py-2 text-sm text-right text-gray-900">{{printf "%.0f" .TotalActions}}</td>
+                            <td class="px-4 py-2 text-sm text-right text-gray-900">{{printf "%.1f" .GeomeanActions}}</td>
+                            <td class="px-4 py-2 text-sm text-gray-600">{{.Description}}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+        </div>
+    </div>
+
+    <!-- Levels -->
+    <div class="max-w-7xl mx-auto px-4 py-8">
+        <div class="bg-white rounded-lg shadow border border-gray-200">
+            <div class="p-6 border-b border-gray-200">
+                <h2 class="text-2xl font-bold text-gray-900">🎮 Level History</h2>
+                <p class="text-sm text-gray-600 mt-2">
+                    The sparkline shows the time of the level in every run, × marks the runs that did not solve it and blanks the runs that did not run it.
+                </p>
+            </div>
+            <div class="overflow-x-auto">
+                <table class="min-w-full divide-y divide-gray-200">
+                    <thead class="bg-gray-50">
+                        <tr>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Level</th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Time</th>
+                            <th class="px-4 py-2 text-right text-xs font-medium text-gray-500 uppercase tracking-wider">Solved</th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">First Solved</th>
+                            <th class="px-4 py-2 text-left text-xs font-medium text-gray-500 uppercase tracking-wider">Regressed In</th>
+                        </tr>
+                    </thead>
+                    <tbody class="bg-white divide-y divide-gray-200">
+                        {{range .Levels}}
+                        <tr>
+                            <td class="px-4 py-2 text-sm font-medium text-gray-900">{{.LevelName}}</td>
+                            <td class="px-4 py-2 text-sm text-blue-600 whitespace-pre sparkline">{{sparkline .TimeSeries}}</td>
+                            <td class="px-4 py-2 text-sm text-right text-gray-900">{{.SolvedRuns}} / {{.PresentRuns}}</td>
+                            <td class="px-4 py-2 text-sm">
+                                {{if .FirstSolved}}<span class="badge badge-success">{{.FirstSolved}}</span>{{else}}<span class="text-red-600">Never</span>{{end}}
+                            </td>
+                            <td class="px-4 py-2 text-sm">
+                                {{range .RegressedIn}}<span class="badge badge-danger">{{.}}</span> {{else}}<span class="text-gray-400">None</span>{{end}}
+                            </td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+            </div>
+        </div>
+    </div>
+
+    <!-- Footer -->
+    <div class="max-w-7xl mx-auto px-4 py-8 text-center text-sm text-gray-500">
+        <p>Generated by masbench trend command</p>
+    </div>
+
+    <script>
+        const runs = {{.Runs}};
+        const labels = runs.map(run => run.name);
+
+        function lineChart(id, datasets, yTitle) {
+            new Chart(document.getElementById(id), {
+                type: 'line',
+                data: { labels: labels, datasets: datasets },
+                options: {
+                    scales: {
+                        y: { beginAtZero: true, title: { display: true, text: yTitle } },
+                    },
+                },
+            });
+        }
+
+        lineChart('solvedChart', [{
+            label: 'Levels solved',
+            data: runs.map(run => run.solved),
+            borderColor: 'rgb(34, 197, 94)',
+            backgroundColor: 'rgb(34, 197, 94)',
+        }], 'Levels');
+
+        lineChart('timeChart', [{
+            label: 'Total time (s)',
+            data: runs.map(run => run.totalTime),
+            borderColor: 'rgb(59, 130, 246)',
+            backgroundColor: 'rgb(59, 130, 246)',
+        }, {
+            label: 'Geomean time (s)',
+            data: runs.map(run => run.geomeanTime),
+            borderColor: 'rgb(249, 115, 22)',
+            backgroundColor: 'rgb(249, 115, 22)',
+        }], 'Seconds');
+
+        lineChart('actionsChart', [{
+            label: 'Total actions',
+            data: runs.map(run => run.totalActions),
+            borderColor: 'rgb(168, 85, 247)',
+            backgroundColor: 'rgb(168, 85, 247)',
+        }, {
+            label: 'Geomean actions',
+            data: runs.map(run => run.geomeanActions),
+            borderColor: 'rgb(20, 184, 166)',
+            backgroundColor: 'rgb(20, 184, 166)',
+        }], 'Actions');
+
+        // Dark mode toggle
+        function toggleDarkMode() {
+            document.body.classList.toggle('dark');
+        }
+    </script>
+</body>
+</html>
+`
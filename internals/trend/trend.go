@@ -0,0 +1,186 @@
+package trend
+
+import (
+	"masbench/internals/models"
+	"masbench/internals/utils"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-gota/gota/dataframe"
+)
+
+// minGeomeanValue is the smallest value used in geometric means, so that a
+// level solved in 0s does not make the mean 0
+const minGeomeanValue = 1e-3
+
+// Input is a benchmark of the series with its results
+type Input struct {
+	Name        string
+	Description string
+	StartedAt   time.Time
+	Results     dataframe.DataFrame
+}
+
+// levelResult is the result of a level in one run, over its repeated runs
+type levelResult struct {
+	solved  bool
+	time    float64
+	actions float64
+}
+
+// Compute orders the benchmarks by the time they were run and follows every
+// level across them. timeout is used for unsolved levels in the total time,
+// levels a benchmark did not run are left out of its numbers
+func Compute(inputs []Input, timeout int) Report {
+	inputs = append([]Input(nil), inputs...)
+	sort.SliceStable(inputs, func(i, j int) bool {
+		return inputs[i].StartedAt.Before(inputs[j].StartedAt)
+	})
+
+	results := make([]map[string]levelResult, len(inputs))
+	levelSet := make(map[string]bool)
+	for i, input := range inputs {
+		results[i] = loadResults(input.Results)
+		for level := range results[i] {
+			levelSet[level] = true
+		}
+	}
+	levels := make([]string, 0, len(levelSet))
+	for level := range levelSet {
+		levels = append(levels, level)
+	}
+	sort.Strings(levels)
+
+	report := Report{
+		Title:       "Benchmark Trend Report",
+		GeneratedAt: time.Now().Format("2006-01-02 15:04:05"),
+		Timeout:     timeout,
+		Runs:        make([]Run, len(inputs)),
+		Levels:      make([]LevelHistory, len(levels)),
+	}
+
+	for i, input := range inputs {
+		run := Run{
+			Name:        input.Name,
+			Description: input.Description,
+			StartedAt:   input.StartedAt,
+			TotalLevels: len(results[i]),
+		}
+		var times, actions []float64
+		for _, level := range levels {
+			result, present := results[i][level]
+			if !present {
+				continue
+			}
+			if !result.solved {
+				run.TotalTime += float64(timeout)
+				continue
+			}
+			run.Solved++
+			run.TotalTime += result.time
+			run.TotalActions += result.actions
+			times = append(times, result.time)
+			actions = append(actions, result.actions)
+		}
+		run.GeomeanTime = geomean(times)
+		run.GeomeanActions = geomean(actions)
+		report.Runs[i] = run
+	}
+
+	for l, level := range levels {
+		history := LevelHistory{
+			LevelName:   level,
+			Present:     make([]bool, len(inputs)),
+			Solved:      make([]bool, len(inputs)),
+			Times:       make([]float64, len(inputs)),
+			Actions:     make([]float64, len(inputs)),
+			RegressedIn: []string{},
+		}
+		previouslySolved := false
+		for i, input := range inputs {
+			result, present := results[i][level]
+			if !present {
+				continue
+			}
+			history.Present[i] = true
+			history.Solved[i] = result.solved
+			if result.solved {
+				history.Times[i] = result.time
+				history.Actions[i] = result.actions
+				if history.FirstSolved == "" {
+					history.FirstSolved = input.Name
+				}
+			} else if previouslySolved {
+				history.RegressedIn = append(history.RegressedIn, input.Name)
+			}
+			previouslySolved = result.solved
+		}
+		report.Levels[l] = history
+	}
+
+	return report
+}
+
+// loadResults returns the result of every level of a benchmark
+func loadResults(df dataframe.DataFrame) map[string]levelResult {
+	results := make(map[string]levelResult)
+	for level, rows := range utils.ToRowsMap(df) {
+		runs := utils.ResolveRuns(rows)
+		result := levelResult{solved: runs.Solved()}
+		result.time, _ = runs.Median(models.ColTime)
+		result.actions, _ = runs.Median(models.ColActions)
+		results[level] = result
+	}
+	return results
+}
+
+// TimeSeries returns the time of a level in every run, +Inf where it was not
+// solved and NaN where it was not run, ready to be drawn as a sparkline
+func (h LevelHistory) TimeSeries() []float64 {
+	values := make([]float64, len(h.Times))
+	for i, value := range h.Times {
+		switch {
+		case !h.Present[i]:
+			values[i] = math.NaN()
+		case !h.Solved[i]:
+			values[i] = math.Inf(1)
+		default:
+			values[i] = value
+		}
+	}
+	return values
+}
+
+// PresentRuns returns the number of runs that ran the level
+func (h LevelHistory) PresentRuns() int {
+	count := 0
+	for _, present := range h.Present {
+		if present {
+			count++
+		}
+	}
+	return count
+}
+
+// SolvedRuns returns the number of runs that solved the level
+func (h LevelHistory) SolvedRuns() int {
+	count := 0
+	for _, solved := range h.Solved {
+		if solved {
+			count++
+		}
+	}
+	return count
+}
+
+func geomean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += math.Log(math.Max(value, minGeomeanValue))
+	}
+	return math.Exp(sum / float64(len(values)))
+}
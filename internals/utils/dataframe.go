@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"strconv"
 
@@ -35,6 +36,32 @@ func ToMap(df dataframe.DataFrame) map[string]map[string]string {
 	return result
 }
 
+// ToRowsMap converts a DataFrame to a map holding every row of each level, in file order.
+// Benchmarks run with repeated runs contain one row per run for the same level
+// Returns: map[levelName][]map[columnName]value
+func ToRowsMap(df dataframe.DataFrame) map[string][]map[string]string {
+	result := make(map[string][]map[string]string)
+	for i := 0; i < df.Nrow(); i++ {
+		levelName := df.Elem(i, 0).String()
+		rowData := make(map[string]string)
+		for j, colName := range df.Names() {
+			rowData[colName] = df.Elem(i, j).String()
+		}
+		result[levelName] = append(result[levelName], rowData)
+	}
+	return result
+}
+
+// ToResolvedMap converts a DataFrame to a map holding one row per level. With
+// repeated runs, the row is the one of LevelRuns.Row
+func ToResolvedMap(df dataframe.DataFrame) map[string]map[string]string {
+	result := make(map[string]map[string]string)
+	for levelName, rows := range ToRowsMap(df) {
+		result[levelName] = ResolveRuns(rows).Row()
+	}
+	return result
+}
+
 // GetColumnIndex finds the column index by name, returns -1 if not found
 func GetColumnIndex(df dataframe.DataFrame, colName string) int {
 	for i, name := range df.Names() {
@@ -78,6 +105,22 @@ func GetFloatFromMap(data map[string]string, colName string) float64 {
 	return val
 }
 
+// LookupFloatFromMap extracts a float64 value from a map
+// Returns ok=false if key not found, the value is empty/NaN or parse error
+func LookupFloatFromMap(data map[string]string, colName string) (float64, bool) {
+	valStr, ok := data[colName]
+	if !ok || valStr == "" {
+		return 0.0, false
+	}
+
+	val, err := strconv.ParseFloat(valStr, 64)
+	if err != nil || math.IsNaN(val) {
+		return 0.0, false
+	}
+
+	return val, true
+}
+
 // GetStringFromDF extracts a string value from a DataFrame at specified row and column
 // Returns empty string if column not found
 func GetStringFromDF(df dataframe.DataFrame, row int, colName string) string {
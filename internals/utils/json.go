@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"masbench/internals/models"
+)
+
+// WriteJSONReport writes report as indented JSON wrapped in the versioned
+// report envelope
+func WriteJSONReport(w io.Writer, kind string, report any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	envelope := models.JSONReport{
+		SchemaVersion: models.ReportSchemaVersion,
+		Kind:          kind,
+		Report:        report,
+	}
+	if err := encoder.Encode(envelope); err != nil {
+		return fmt.Errorf("failed to encode JSON report: %w", err)
+	}
+	return nil
+}
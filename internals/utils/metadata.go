@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"fmt"
+	"masbench/internals/models"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MetadataPath returns the path of the metadata file of a benchmark
+func MetadataPath(benchmarkFolder, name string) string {
+	return filepath.Join(benchmarkFolder, name, name+"_meta.yml")
+}
+
+// WriteMetadata saves the metadata of a benchmark
+func WriteMetadata(benchmarkFolder string, meta models.RunMetadata) error {
+	data, err := yaml.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	if err := os.WriteFile(MetadataPath(benchmarkFolder, meta.Name), data, 0644); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
+	return nil
+}
+
+// LoadMetadata reads the metadata of a benchmark. Benchmarks run before
+// metadata was recorded get the modification time of their results as
+// StartedAt
+func LoadMetadata(benchmarkFolder, name string) (models.RunMetadata, error) {
+	data, err := os.ReadFile(MetadataPath(benchmarkFolder, name))
+	if os.IsNotExist(err) {
+		resultsPath := filepath.Join(benchmarkFolder, name, fmt.Sprintf("%s_results.csv", name))
+		info, err := os.Stat(resultsPath)
+		if err != nil {
+			return models.RunMetadata{}, fmt.Errorf("failed to read results of %s: %w", name, err)
+		}
+		return models.RunMetadata{Name: name, StartedAt: info.ModTime()}, nil
+	}
+	if err != nil {
+		return models.RunMetadata{}, fmt.Errorf("failed to read metadata of %s: %w", name, err)
+	}
+
+	var meta models.RunMetadata
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return models.RunMetadata{}, fmt.Errorf("failed to parse metadata of %s: %w", name, err)
+	}
+	meta.Name = name
+	return meta, nil
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"maps"
+	"masbench/internals/models"
+	"sort"
+	"strconv"
+)
+
+// LevelRuns holds the rows of a level, one per run. With repeated runs the
+// level is solved when the majority of its runs are, and its metrics are the
+// medians over the runs with a value
+type LevelRuns struct {
+	Rows       []map[string]string
+	SolvedRuns int
+}
+
+// ResolveRuns returns the runs of a level from its rows
+func ResolveRuns(rows []map[string]string) LevelRuns {
+	level := LevelRuns{Rows: rows}
+	for _, row := range rows {
+		if GetStringFromMap(row, models.ColSolved) == models.SolvedYes {
+			level.SolvedRuns++
+		}
+	}
+	return level
+}
+
+// Solved reports whether the majority of the runs solved the level
+func (l LevelRuns) Solved() bool {
+	return l.SolvedRuns*2 > len(l.Rows)
+}
+
+// Samples returns the values of a column over the runs with a value
+func (l LevelRuns) Samples(colName string) []float64 {
+	var samples []float64
+	for _, row := range l.Rows {
+		if value, ok := LookupFloatFromMap(row, colName); ok {
+			samples = append(samples, value)
+		}
+	}
+	return samples
+}
+
+// Median returns the median of a column over the runs, ok=false if no run
+// has a value for it
+func (l LevelRuns) Median(colName string) (float64, bool) {
+	samples := l.Samples(colName)
+	return Median(samples), len(samples) > 0
+}
+
+// Row returns a single row standing for the runs: solved by majority, with
+// the median of every column some run has a number for. Other columns keep
+// the value of the first run
+func (l LevelRuns) Row() map[string]string {
+	if len(l.Rows) == 0 {
+		return nil
+	}
+	row := maps.Clone(l.Rows[0])
+	for colName := range row {
+		if median, ok := l.Median(colName); ok {
+			row[colName] = strconv.FormatFloat(median, 'f', -1, 64)
+		}
+	}
+	row[models.ColSolved] = models.SolvedNo
+	if l.Solved() {
+		row[models.ColSolved] = models.SolvedYes
+	}
+	return row
+}
+
+// Median returns the median of values, 0 for an empty slice
+func Median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}